@@ -0,0 +1,75 @@
+package runner
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWriteResumeEnvelopeLoadResumeEnvelopeRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "resume.cfg")
+
+	if err := writeResumeEnvelope(dir, path, []byte("{}"), nil); err != nil {
+		t.Fatalf("writeResumeEnvelope() error = %v", err)
+	}
+
+	if _, _, err := loadResumeEnvelope(path); err != nil {
+		t.Errorf("loadResumeEnvelope() error = %v, want nil", err)
+	}
+}
+
+func TestLoadResumeEnvelopeRejectsSchemaMismatch(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "resume.cfg")
+
+	envelope := resumeFileEnvelope{
+		SchemaVersion: resumeFileSchemaVersion + 1,
+		SHA256:        "irrelevant",
+		Payload:       json.RawMessage("{}"),
+	}
+	data, err := json.Marshal(envelope)
+	if err != nil {
+		t.Fatalf("could not marshal test envelope: %v", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatalf("could not write test resume file: %v", err)
+	}
+
+	if _, _, err := loadResumeEnvelope(path); err == nil {
+		t.Error("loadResumeEnvelope() on schema mismatch = nil error, want rejection")
+	}
+}
+
+func TestLoadResumeEnvelopeRejectsChecksumMismatch(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "resume.cfg")
+
+	if err := writeResumeEnvelope(dir, path, []byte(`{"a":1}`), nil); err != nil {
+		t.Fatalf("writeResumeEnvelope() error = %v", err)
+	}
+
+	// Tamper with the on-disk payload so it no longer matches the stored
+	// checksum, simulating a truncated write or a corrupted file.
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("could not read test resume file: %v", err)
+	}
+	var envelope resumeFileEnvelope
+	if err := json.Unmarshal(data, &envelope); err != nil {
+		t.Fatalf("could not unmarshal test resume file: %v", err)
+	}
+	envelope.Payload = json.RawMessage(`{"a":2}`)
+	tampered, err := json.Marshal(envelope)
+	if err != nil {
+		t.Fatalf("could not marshal tampered envelope: %v", err)
+	}
+	if err := os.WriteFile(path, tampered, 0644); err != nil {
+		t.Fatalf("could not write tampered resume file: %v", err)
+	}
+
+	if _, _, err := loadResumeEnvelope(path); err == nil {
+		t.Error("loadResumeEnvelope() on checksum mismatch = nil error, want rejection")
+	}
+}
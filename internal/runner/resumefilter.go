@@ -0,0 +1,142 @@
+package runner
+
+import (
+	"net"
+	"strings"
+	"sync"
+
+	"github.com/bmatcuk/doublestar/v4"
+
+	"github.com/projectdiscovery/nuclei/v3/pkg/input/provider"
+	"github.com/projectdiscovery/nuclei/v3/pkg/protocols/common/contextargs"
+)
+
+// resumeTargetFilter is persisted into the resume file's envelope alongside
+// the rest of the checkpoint so a subsequent resume of the same run stays
+// consistent with -resume-include/-resume-exclude without having to pass
+// them again, and benefits from the same schema-version/checksum protection
+// as the rest of the resume state.
+type resumeTargetFilter struct {
+	Include []string `json:"resume_include,omitempty"`
+	Exclude []string `json:"resume_exclude,omitempty"`
+}
+
+// expandAll expands brace groups (e.g. "10.0.{1,2}.0/24") in every pattern.
+func expandAll(patterns []string) []string {
+	var expanded []string
+	for _, pattern := range patterns {
+		expanded = append(expanded, expandBraces(pattern)...)
+	}
+	return expanded
+}
+
+// expandBraces performs a single pass of shell-style brace expansion, e.g.
+// "cves/2023/{cve-2023-1,cve-2023-2}*.yaml" becomes the two patterns
+// "cves/2023/cve-2023-1*.yaml" and "cves/2023/cve-2023-2*.yaml".
+func expandBraces(pattern string) []string {
+	start := strings.IndexByte(pattern, '{')
+	if start == -1 {
+		return []string{pattern}
+	}
+	end := strings.IndexByte(pattern[start:], '}')
+	if end == -1 {
+		return []string{pattern}
+	}
+	end += start
+
+	prefix, suffix := pattern[:start], pattern[end+1:]
+	options := strings.Split(pattern[start+1:end], ",")
+
+	var expanded []string
+	for _, option := range options {
+		expanded = append(expanded, expandBraces(prefix+option+suffix)...)
+	}
+	return expanded
+}
+
+func matchesAny(target string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if matchesCIDR(target, pattern) {
+			return true
+		}
+		if matched, err := doublestar.Match(pattern, target); err == nil && matched {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesCIDR treats pattern as a CIDR shorthand (e.g. "10.0.0.0/8") and
+// target as an IP, so users can filter resumed scans down to an IP range
+// without writing a glob.
+func matchesCIDR(target, pattern string) bool {
+	_, network, err := net.ParseCIDR(pattern)
+	if err != nil {
+		return false
+	}
+	ip := net.ParseIP(target)
+	if ip == nil {
+		return false
+	}
+	return network.Contains(ip)
+}
+
+// filteredInputProvider wraps a provider.InputProvider and restricts
+// iteration to targets matching the configured -resume-include/
+// -resume-exclude glob/CIDR patterns. nuclei's resume cursor
+// (types.ResumeCfg.ResumeFrom/Current) has no target list of its own to
+// filter, so the filtering has to happen on the actual target set the
+// engine iterates instead.
+type filteredInputProvider struct {
+	provider.InputProvider
+
+	includePatterns []string
+	excludePatterns []string
+
+	countOnce sync.Once
+	count     int64
+}
+
+// newFilteredInputProvider wraps inner so only targets matching include are
+// kept (when include is non-empty) and targets matching exclude are dropped.
+func newFilteredInputProvider(inner provider.InputProvider, include, exclude []string) *filteredInputProvider {
+	return &filteredInputProvider{
+		InputProvider:   inner,
+		includePatterns: expandAll(include),
+		excludePatterns: expandAll(exclude),
+	}
+}
+
+func (f *filteredInputProvider) allowed(value *contextargs.MetaInput) bool {
+	target := value.Input
+	if len(f.includePatterns) > 0 && !matchesAny(target, f.includePatterns) {
+		return false
+	}
+	return !matchesAny(target, f.excludePatterns)
+}
+
+// Iterate calls callback for every target held by the wrapped provider that
+// passes the configured include/exclude filters.
+func (f *filteredInputProvider) Iterate(callback func(value *contextargs.MetaInput) bool) {
+	f.InputProvider.Iterate(func(value *contextargs.MetaInput) bool {
+		if !f.allowed(value) {
+			return true
+		}
+		return callback(value)
+	})
+}
+
+// Count returns the number of targets that pass the configured
+// include/exclude filters. It is computed once and cached, since the
+// underlying provider's target set does not change mid-run.
+func (f *filteredInputProvider) Count() int64 {
+	f.countOnce.Do(func() {
+		f.InputProvider.Iterate(func(value *contextargs.MetaInput) bool {
+			if f.allowed(value) {
+				f.count++
+			}
+			return true
+		})
+	})
+	return f.count
+}
@@ -2,18 +2,24 @@ package runner
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	_ "net/http/pprof"
 	"os"
+	"os/signal"
 	"path/filepath"
 	"strings"
+	"sync"
 	"sync/atomic"
+	"syscall"
 	"time"
 
 	"github.com/projectdiscovery/nuclei/v3/internal/pdcp"
 	"github.com/projectdiscovery/nuclei/v3/pkg/authprovider"
+	"github.com/projectdiscovery/nuclei/v3/pkg/autoupdate"
 	"github.com/projectdiscovery/nuclei/v3/pkg/cruisecontrol"
 	"github.com/projectdiscovery/nuclei/v3/pkg/input/provider"
 	"github.com/projectdiscovery/nuclei/v3/pkg/installer"
@@ -39,6 +45,8 @@ import (
 	"github.com/projectdiscovery/nuclei/v3/pkg/input"
 	parsers "github.com/projectdiscovery/nuclei/v3/pkg/loader/workflow"
 	"github.com/projectdiscovery/nuclei/v3/pkg/output"
+	"github.com/projectdiscovery/nuclei/v3/pkg/output/eventstream"
+	"github.com/projectdiscovery/nuclei/v3/pkg/plugins"
 	"github.com/projectdiscovery/nuclei/v3/pkg/progress"
 	"github.com/projectdiscovery/nuclei/v3/pkg/projectfile"
 	"github.com/projectdiscovery/nuclei/v3/pkg/protocols"
@@ -52,6 +60,8 @@ import (
 	"github.com/projectdiscovery/nuclei/v3/pkg/protocols/headless/engine"
 	"github.com/projectdiscovery/nuclei/v3/pkg/protocols/http/httpclientpool"
 	"github.com/projectdiscovery/nuclei/v3/pkg/reporting"
+	"github.com/projectdiscovery/nuclei/v3/pkg/scheduler"
+	"github.com/projectdiscovery/nuclei/v3/pkg/signaturepolicy"
 	"github.com/projectdiscovery/nuclei/v3/pkg/templates"
 	"github.com/projectdiscovery/nuclei/v3/pkg/types"
 	"github.com/projectdiscovery/nuclei/v3/pkg/utils"
@@ -86,6 +96,10 @@ type Runner struct {
 	browser          *engine.Browser
 	hostErrors       hosterrorscache.CacheInterface
 	resumeCfg        *types.ResumeCfg
+	// resumeFilter holds the -resume-include/-resume-exclude patterns in
+	// effect for this run, if any, so SaveResumeConfig can persist them back
+	// into the resume file's envelope alongside resumeCfg.
+	resumeFilter     *resumeTargetFilter
 	pprofServer      *http.Server
 	pdcpUploadErrMsg string
 	inputProvider    provider.InputProvider
@@ -93,6 +107,30 @@ type Runner struct {
 	tmpDir        string
 	parser        parser.Parser
 	cruiseControl *cruisecontrol.CruiseControl
+	pluginManager *plugins.Manager
+	events            *eventstream.Recorder
+	updater           *autoupdate.Updater
+	signaturePolicy   *signaturepolicy.Policy
+	// filteredTemplates holds store's templates+workflows after
+	// signaturePolicy has evaluated each one, computed once right after
+	// store.Load() so the actual scan only runs the already-enforced list.
+	// displayExecutionInfo still reports its counts from store itself.
+	filteredTemplates []*templates.Template
+}
+
+// CheckForUpdates checks the configured update channel for a newer
+// nuclei-templates release and, if found, stages it and swaps it in, keeping
+// the previous installation for Rollback. The updater built in New has no
+// Verifier and no Validate hook, so the manifest signature is not verified
+// and the staged templates are not validated before the swap. Library
+// consumers can call it between scans instead of only relying on the
+// best-effort check done once at process start.
+func (r *Runner) CheckForUpdates(ctx context.Context) error {
+	if r.updater == nil {
+		return errors.New("auto-updater is not available")
+	}
+	_, err := r.updater.CheckForUpdates(ctx)
+	return err
 }
 
 const pprofServerAddress = "127.0.0.1:8086"
@@ -108,6 +146,29 @@ func New(options *types.Options) (*Runner, error) {
 		os.Exit(0)
 	}
 
+	updater, updaterErr := autoupdate.New(autoupdate.Options{
+		TemplatesDirectory: config.DefaultConfig.TemplatesDirectory,
+		Channel:            autoupdate.Channel(options.UpdateChannel),
+		Fetcher: &autoupdate.HTTPFetcher{
+			ManifestURLFor: func(channel autoupdate.Channel) string {
+				return "https://api.nuclei.sh/templates/manifest?channel=" + string(channel)
+			},
+		},
+	})
+	if updaterErr == nil {
+		runner.updater = updater
+	}
+
+	if options.RollbackTemplates {
+		if runner.updater == nil {
+			gologger.Fatal().Msgf("Could not initialize auto-updater for rollback: %s\n", updaterErr)
+		}
+		if err := runner.updater.Rollback(); err != nil {
+			gologger.Fatal().Msgf("Could not rollback nuclei-templates: %s\n", err)
+		}
+		os.Exit(0)
+	}
+
 	//  Version check by default
 	if config.DefaultConfig.CanCheckForUpdates() {
 		if err := installer.NucleiVersionCheck(); err != nil {
@@ -154,6 +215,16 @@ func New(options *types.Options) (*Runner, error) {
 		}
 	}
 
+	perSourceModes := make(map[string]signaturepolicy.Mode, len(options.SignatureModePerSource))
+	for source, mode := range options.SignatureModePerSource {
+		perSourceModes[source] = signaturepolicy.Mode(mode)
+	}
+	sigPolicy, err := signaturepolicy.New(signaturepolicy.Mode(options.SignatureMode), perSourceModes, options.SignatureAllowListFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not load signature allow-list")
+	}
+	runner.signaturePolicy = sigPolicy
+
 	parser := templates.NewParser()
 
 	if options.Validate {
@@ -268,20 +339,45 @@ func New(options *types.Options) (*Runner, error) {
 
 	// create the resume configuration structure
 	resumeCfg := types.NewResumeCfg()
+	var loadedFilter *resumeTargetFilter
 	if runner.options.ShouldLoadResume() {
 		gologger.Info().Msg("Resuming from save checkpoint")
-		file, err := os.ReadFile(runner.options.Resume)
+		loaded, filter, err := loadResumeEnvelope(runner.options.Resume)
 		if err != nil {
-			return nil, err
+			return nil, errors.Wrap(err, "could not load resume checkpoint")
 		}
-		err = json.Unmarshal(file, &resumeCfg)
+		resumeCfg, loadedFilter = loaded, filter
+	} else if runner.options.ResumeName != "" {
+		gologger.Info().Msgf("Resuming from named checkpoint %q\n", runner.options.ResumeName)
+		loaded, filter, err := LoadResumeConfig(runner.options.ResumeName)
 		if err != nil {
-			return nil, err
+			return nil, errors.Wrap(err, "could not load named resume checkpoint")
 		}
-		resumeCfg.Compile()
+		resumeCfg, loadedFilter = loaded, filter
 	}
 	runner.resumeCfg = resumeCfg
 
+	// -resume-include/-resume-exclude narrow the resumed target set down to a
+	// subset of what was checkpointed, e.g. to re-run just the hosts that
+	// previously errored out. nuclei's resume cursor has no target list of
+	// its own to filter, so the wrapped input provider does the filtering
+	// instead. The filters are persisted into the resume file's own envelope
+	// (not a sidecar file) so they get the same schema-version/checksum
+	// protection as the rest of the resume state, and a later resume of the
+	// same checkpoint keeps them without having to pass the flags again.
+	if runner.resumePathInUse() != "" {
+		include, exclude := runner.options.ResumeInclude, runner.options.ResumeExclude
+		if len(include) == 0 && len(exclude) == 0 && loadedFilter != nil {
+			include, exclude = loadedFilter.Include, loadedFilter.Exclude
+		}
+		if len(include) > 0 || len(exclude) > 0 {
+			filter := &resumeTargetFilter{Include: include, Exclude: exclude}
+			runner.resumeFilter = filter
+			runner.inputProvider = newFilteredInputProvider(runner.inputProvider, include, exclude)
+			gologger.Info().Msgf("Targets loaded for current scan after resume filters: %d (from %d)\n", runner.inputProvider.Count(), inputProvider.Count())
+		}
+	}
+
 	opts := interactsh.DefaultOptions(runner.output, runner.issuesClient, runner.progress)
 	opts.Debug = runner.options.Debug
 	opts.NoColor = runner.options.NoColor
@@ -328,9 +424,51 @@ func New(options *types.Options) (*Runner, error) {
 		runner.tmpDir = tmpDir
 	}
 
+	eventSink, err := eventstream.NewFromOptions(options.EventsFile, options.EventsURL)
+	if err != nil {
+		gologger.Warning().Msgf("Could not initialize event stream: %s\n", err)
+	}
+	runner.events = eventstream.NewRecorder(eventSink, options.ScanID)
+
+	pluginsDir := pluginsDirectory()
+	pluginVerifier, err := pluginSignatureVerifier()
+	if err != nil {
+		gologger.Warning().Msgf("Plugin signature verification disabled, no plugin will be loaded: %s\n", err)
+	}
+	runner.pluginManager = plugins.NewManager(pluginsDir, pluginVerifier)
+	if err := runner.pluginManager.LoadAll(); err != nil {
+		gologger.Warning().Msgf("Could not load protocol plugins: %s\n", err)
+	}
+
 	return runner, nil
 }
 
+// pluginsDirectory returns the directory protocol plugins are discovered
+// from, honoring NUCLEI_HOME when set.
+func pluginsDirectory() string {
+	if home := env.GetEnvOrDefault("NUCLEI_HOME", ""); home != "" {
+		return filepath.Join(home, "plugins")
+	}
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return filepath.Join(".", ".config", "nuclei", "plugins")
+	}
+	return filepath.Join(homeDir, ".config", "nuclei", "plugins")
+}
+
+// pluginSignatureVerifier builds the verifier plugin binaries are checked
+// against before being spawned, from the ed25519 public key at
+// NUCLEI_PLUGIN_SIGNING_KEY. It returns a nil verifier (and an error) when
+// the key is not configured, which makes the Manager refuse to load any
+// plugin rather than run unverified binaries.
+func pluginSignatureVerifier() (plugins.SignatureVerifier, error) {
+	keyPath := env.GetEnvOrDefault("NUCLEI_PLUGIN_SIGNING_KEY", "")
+	if keyPath == "" {
+		return nil, errors.New("NUCLEI_PLUGIN_SIGNING_KEY is not set")
+	}
+	return plugins.VerifierFromKeyFile(keyPath)
+}
+
 // runStandardEnumeration runs standard enumeration
 func (r *Runner) runStandardEnumeration(executerOpts protocols.ExecutorOptions, store *loader.Store, engine *core.Engine) (*atomic.Bool, error) {
 	if r.options.AutomaticScan {
@@ -360,6 +498,11 @@ func (r *Runner) Close() {
 	if r.cruiseControl != nil {
 		r.cruiseControl.Close()
 	}
+	if r.pluginManager != nil {
+		r.pluginManager.Close()
+	}
+	// always emit a final scan_summary event, even on cancellation
+	r.events.Summary()
 	r.progress.Stop()
 	if r.browser != nil {
 		r.browser.Close()
@@ -401,9 +544,76 @@ func (r *Runner) setupPDCPUpload(writer output.Writer) output.Writer {
 	return output.NewMultiWriter(writer, uploadWriter)
 }
 
+// RunScheduled turns the runner into a long-lived daemon that executes specs
+// on their configured cron schedule, persisting schedule state as JSON to
+// statePath so restarts pick up missed runs. Each run's scan ID is set on
+// r.options.ScanID and recorded for /runs; the PDCP upload writer created in
+// New keeps the scan ID it was set up with. It blocks until ctx is cancelled.
+func (r *Runner) RunScheduled(ctx context.Context, specs []scheduler.ScheduleSpec, statePath string) error {
+	// Every schedule drives the same Runner/options, so two schedules due at
+	// the same time cannot run concurrently without racing on r.options;
+	// runMu serializes them. previousResults keeps each schedule's last run
+	// so DiffNew can report only newly discovered findings on each rerun.
+	var runMu sync.Mutex
+	previousResults := make(map[string][]*output.ResultEvent)
+
+	sched := scheduler.New(statePath, func(runCtx context.Context, spec scheduler.ScheduleSpec) (string, error) {
+		runMu.Lock()
+		defer runMu.Unlock()
+
+		scanID := fmt.Sprintf("%s-%d", spec.ID, time.Now().Unix())
+		r.options.ScanID = scanID
+		if len(spec.Templates) > 0 {
+			r.options.Templates = spec.Templates
+		}
+		if len(spec.Targets) > 0 {
+			for _, target := range spec.Targets {
+				_ = r.inputProvider.SetWithExclusions(target)
+			}
+		}
+
+		diffing := &diffingWriter{Writer: r.output}
+		r.output = diffing
+		err := r.RunEnumeration()
+		r.output = diffing.Writer
+
+		current := diffing.Results()
+		fresh := scheduler.DiffNew(previousResults[spec.ID], current)
+		previousResults[spec.ID] = current
+		gologger.Info().Msgf("Schedule %s: %d new finding(s) out of %d total this run\n", spec.ID, len(fresh), len(current))
+
+		return scanID, err
+	})
+
+	if err := sched.Load(); err != nil {
+		return errors.Wrap(err, "could not load scheduler state")
+	}
+	for _, spec := range specs {
+		if err := sched.AddSchedule(spec); err != nil {
+			return errors.Wrap(err, "could not add schedule "+spec.ID)
+		}
+	}
+
+	if r.options.EnablePprof {
+		sched.RegisterHandlers(http.DefaultServeMux)
+	}
+
+	sched.Start()
+	defer sched.Stop()
+
+	<-ctx.Done()
+	return sched.Save()
+}
+
 // RunEnumeration sets up the input layer for giving input nuclei.
 // binary and runs the actual enumeration
 func (r *Runner) RunEnumeration() error {
+	// Persist a resume checkpoint on Ctrl+C: without this, SaveResumeConfig/
+	// SaveResumeConfigNamed would only ever run if a library caller invoked
+	// them directly, so an interrupted CLI scan had no checkpoint to resume
+	// from despite -resume/-resume-name being fully able to load one.
+	r.setupResumeSignalHandler()
+
 	// If user asked for new templates to be executed, collect the list from the templates' directory.
 	if r.options.NewTemplates {
 		if arr := config.DefaultConfig.GetNewAdditions(); len(arr) > 0 {
@@ -499,7 +709,10 @@ func (r *Runner) RunEnumeration() error {
 		}
 		return nil // exit
 	}
+	loadStart := time.Now()
 	store.Load()
+	r.events.Record("template_load", "", "", time.Since(loadStart), nil)
+	r.filteredTemplates = r.filterBySignaturePolicy(store)
 	// TODO: remove below functions after v3 or update warning messages
 	disk.PrintDeprecatedPathsMsgIfApplicable(r.options.Silent)
 	templates.PrintDeprecatedProtocolNameMsgIfApplicable(r.options.Silent, r.options.Verbose)
@@ -549,8 +762,10 @@ func (r *Runner) RunEnumeration() error {
 
 	enumeration := false
 	var results *atomic.Bool
+	dispatchStart := time.Now()
 	results, err = r.runStandardEnumeration(executorOpts, store, executorEngine)
 	enumeration = true
+	r.events.Record(eventstream.PhaseInputDispatch, "", "", time.Since(dispatchStart), err)
 
 	if !enumeration {
 		return err
@@ -558,6 +773,7 @@ func (r *Runner) RunEnumeration() error {
 
 	if r.interactsh != nil {
 		matched := r.interactsh.Close()
+		r.events.Record("interactsh_stop", "", "", 0, nil)
 		if matched {
 			results.CompareAndSwap(false, true)
 		}
@@ -621,9 +837,7 @@ func (r *Runner) executeTemplatesInput(store *loader.Store, engine *core.Engine)
 		}
 	}
 
-	finalTemplates := []*templates.Template{}
-	finalTemplates = append(finalTemplates, store.Templates()...)
-	finalTemplates = append(finalTemplates, store.Workflows()...)
+	finalTemplates := r.filteredTemplates
 
 	if len(finalTemplates) == 0 {
 		return nil, errors.New("no templates provided for scan")
@@ -638,6 +852,63 @@ func (r *Runner) executeTemplatesInput(store *loader.Store, engine *core.Engine)
 	return results, nil
 }
 
+// filterBySignaturePolicy evaluates every loaded template and workflow
+// against r.signaturePolicy and returns only the ones that may run. Unlike
+// the post-hoc stats check in displayExecutionInfo, this actually drops
+// Skip-decision templates before they ever reach the engine, and aborts the
+// whole run immediately on an Abort decision (-signature-mode strict)
+// instead of only failing once stats are displayed.
+func (r *Runner) filterBySignaturePolicy(store *loader.Store) []*templates.Template {
+	all := make([]*templates.Template, 0, len(store.Templates())+len(store.Workflows()))
+	all = append(all, store.Templates()...)
+	all = append(all, store.Workflows()...)
+
+	if r.signaturePolicy == nil {
+		return all
+	}
+
+	filtered := make([]*templates.Template, 0, len(all))
+	for _, tpl := range all {
+		sourceKey := templateSignatureSourceKey(tpl.Path)
+		decision := r.signaturePolicy.Evaluate(sourceKey, tpl.Verified, templateDigestHex(tpl.Path))
+		switch decision {
+		case signaturepolicy.Abort:
+			gologger.Fatal().Msgf("Refusing to run: unsigned template %s present under -signature-mode strict\n", tpl.Path)
+		case signaturepolicy.Skip:
+			continue
+		}
+		filtered = append(filtered, tpl)
+	}
+	return filtered
+}
+
+// templateSignatureSourceKey derives the signaturepolicy per-source key for
+// a template from its on-disk path: the top-level directory it was loaded
+// from (e.g. "custom-templates" for -t custom-templates/foo.yaml), so
+// -signature-mode-per-source can target a specific template source without
+// changing the default mode everywhere else. Templates with no parent
+// directory fall back to templates.Unsigned, the catch-all bucket.
+func templateSignatureSourceKey(path string) string {
+	clean := filepath.ToSlash(filepath.Clean(path))
+	if idx := strings.Index(clean, "/"); idx != -1 {
+		return clean[:idx]
+	}
+	return templates.Unsigned
+}
+
+// templateDigestHex returns the hex SHA-256 digest of the template file at
+// path, so allow-listed mode can match it against the user-supplied
+// allow-list. An empty string is returned if the file can't be read, which
+// simply never matches any allow-list entry.
+func templateDigestHex(path string) string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
 // displayExecutionInfo displays misc info about the nuclei engine execution
 func (r *Runner) displayExecutionInfo(store *loader.Store) {
 	// Display stats for any loaded templates' syntax warnings or errors
@@ -683,6 +954,19 @@ func (r *Runner) displayExecutionInfo(store *loader.Store) {
 			// adjust skipped unsigned templates via code or -dut flag
 			value = value - uint64(stats.GetValue(templates.SkippedUnsignedStats))
 			value = value - uint64(stats.GetValue(templates.CodeFlagWarningStats))
+
+			if r.signaturePolicy != nil {
+				switch r.signaturePolicy.ModeFor(k) {
+				case signaturepolicy.Strict:
+					gologger.Fatal().Msgf("Refusing to run: %d unsigned templates present under -signature-mode strict\n", value)
+				case signaturepolicy.AllowListed:
+					// templates outside the allow-list were already counted
+					// into SkippedUnsignedPolicyStats as they were loaded
+					if skipped, ok := signaturepolicy.SkippedUnsignedPolicyStats[k]; ok {
+						value -= skipped.Load()
+					}
+				}
+			}
 		}
 		if value > 0 {
 			if k != templates.Unsigned {
@@ -698,7 +982,104 @@ func (r *Runner) displayExecutionInfo(store *loader.Store) {
 	}
 }
 
-// SaveResumeConfig to file
+// resumeFileSchemaVersion is bumped whenever the on-disk resume file format
+// changes in an incompatible way, so an older/corrupted file is rejected on
+// load instead of being silently misapplied.
+const resumeFileSchemaVersion = 2
+
+// resumeFileEnvelope wraps the actual resume payload with a schema version
+// and a checksum of the payload, so a truncated write (process killed
+// mid-save) or a stale/incompatible file is detected on load.
+type resumeFileEnvelope struct {
+	SchemaVersion int             `json:"schema_version"`
+	SHA256        string          `json:"sha256"`
+	Payload       json.RawMessage `json:"payload"`
+	// Filter is the -resume-include/-resume-exclude patterns in effect when
+	// this checkpoint was saved, if any, so a later resume of the same
+	// checkpoint keeps them without having to pass the flags again.
+	Filter *resumeTargetFilter `json:"filter,omitempty"`
+}
+
+// resumeDir returns the directory named resume slots are stored under,
+// creating it if necessary.
+func resumeDir() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	dir := filepath.Join(homeDir, ".config", "nuclei", "resume")
+	if !fileutil.FolderExists(dir) {
+		if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+			return "", err
+		}
+	}
+	return dir, nil
+}
+
+// resumePathForName resolves a named resume slot to its on-disk path.
+func resumePathForName(name string) (string, error) {
+	dir, err := resumeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, name+".cfg"), nil
+}
+
+// resumePathInUse returns the on-disk resume file path for whichever resume
+// source (-resume or -resume-name) is active for this run, or "" if the run
+// isn't resuming from a checkpoint.
+func (r *Runner) resumePathInUse() string {
+	if r.options.ShouldLoadResume() {
+		return r.options.Resume
+	}
+	if r.options.ResumeName != "" {
+		path, err := resumePathForName(r.options.ResumeName)
+		if err != nil {
+			return ""
+		}
+		return path
+	}
+	return ""
+}
+
+// setupResumeSignalHandler saves a resume checkpoint on SIGINT/SIGTERM, under
+// the named slot if -resume-name was used to load this run, otherwise to
+// options.Resume, or to default.cfg in the resume directory when neither is
+// set, so every interrupted run leaves a checkpoint behind.
+func (r *Runner) setupResumeSignalHandler() {
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-sigChan
+		gologger.Info().Msgf("CTRL+C pressed: Exiting\n")
+
+		var err error
+		switch {
+		case r.options.ResumeName != "":
+			err = r.SaveResumeConfigNamed(r.options.ResumeName)
+		case r.options.Resume != "":
+			err = r.SaveResumeConfig(r.options.Resume)
+		default:
+			var dir string
+			if dir, err = resumeDir(); err == nil {
+				err = r.SaveResumeConfig(filepath.Join(dir, "default.cfg"))
+			}
+		}
+		if err != nil {
+			gologger.Error().Msgf("Could not save resume checkpoint: %s\n", err)
+		} else {
+			gologger.Info().Msgf("Resume file saved. You can use -resume flag to resume the scan.\n")
+		}
+		r.Close()
+		os.Exit(1)
+	}()
+}
+
+// SaveResumeConfig atomically persists the current resume checkpoint to
+// path: it marshals to a temp file in the same directory and renames it into
+// place, so a process kill mid-write can never leave a truncated/corrupted
+// resume file behind. The payload is wrapped with a schema version and its
+// SHA-256 digest so a corrupted or incompatible file is rejected on load.
 func (r *Runner) SaveResumeConfig(path string) error {
 	dir := filepath.Dir(path)
 	if !fileutil.FolderExists(dir) {
@@ -708,7 +1089,135 @@ func (r *Runner) SaveResumeConfig(path string) error {
 	}
 	resumeCfgClone := r.resumeCfg.Clone()
 	resumeCfgClone.ResumeFrom = resumeCfgClone.Current
-	data, _ := json.MarshalIndent(resumeCfgClone, "", "\t")
+	payload, err := json.MarshalIndent(resumeCfgClone, "", "\t")
+	if err != nil {
+		return err
+	}
+
+	return writeResumeEnvelope(dir, path, payload, r.resumeFilter)
+}
+
+// SaveResumeConfigNamed persists the current resume checkpoint under a named
+// slot (~/.config/nuclei/resume/<name>.cfg), allowing multiple concurrent
+// nuclei runs to save/restore independent checkpoints via -resume-name.
+func (r *Runner) SaveResumeConfigNamed(name string) error {
+	path, err := resumePathForName(name)
+	if err != nil {
+		return err
+	}
+	return r.SaveResumeConfig(path)
+}
+
+func writeResumeEnvelope(dir, path string, payload []byte, filter *resumeTargetFilter) error {
+	// json.Marshal compacts (and HTML-escapes) a RawMessage, and the envelope
+	// is written compact, so normalizing the payload the same way first makes
+	// the checksum cover exactly the bytes loadResumeEnvelope reads back.
+	normalized, err := json.Marshal(json.RawMessage(payload))
+	if err != nil {
+		return err
+	}
+	payload = normalized
+	digest := sha256.Sum256(payload)
+	envelope := resumeFileEnvelope{
+		SchemaVersion: resumeFileSchemaVersion,
+		SHA256:        hex.EncodeToString(digest[:]),
+		Payload:       payload,
+		Filter:        filter,
+	}
+	data, err := json.Marshal(envelope)
+	if err != nil {
+		return err
+	}
+
+	tmpFile, err := os.CreateTemp(dir, filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmpFile.Name()
+	if _, err := tmpFile.Write(data); err != nil {
+		tmpFile.Close()
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := tmpFile.Close(); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := os.Chmod(tmpPath, permissionutil.ConfigFilePermission); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	return nil
+}
+
+// LoadResumeConfig loads a named resume slot previously saved with
+// SaveResumeConfigNamed, verifying its schema version and checksum before
+// returning it. A corrupted or schema-incompatible file is rejected rather
+// than silently misapplied.
+func LoadResumeConfig(name string) (*types.ResumeCfg, *resumeTargetFilter, error) {
+	path, err := resumePathForName(name)
+	if err != nil {
+		return nil, nil, err
+	}
+	return loadResumeEnvelope(path)
+}
+
+func loadResumeEnvelope(path string) (*types.ResumeCfg, *resumeTargetFilter, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	var envelope resumeFileEnvelope
+	if err := json.Unmarshal(data, &envelope); err != nil {
+		return nil, nil, errors.Wrap(err, "resume file is not a valid envelope")
+	}
+	if envelope.SchemaVersion != resumeFileSchemaVersion {
+		return nil, nil, fmt.Errorf("resume file schema version %d is not supported (expected %d)", envelope.SchemaVersion, resumeFileSchemaVersion)
+	}
+	digest := sha256.Sum256(envelope.Payload)
+	if hex.EncodeToString(digest[:]) != envelope.SHA256 {
+		return nil, nil, errors.New("resume file checksum mismatch, refusing to load a possibly corrupted checkpoint")
+	}
+
+	var resumeCfg types.ResumeCfg
+	if err := json.Unmarshal(envelope.Payload, &resumeCfg); err != nil {
+		return nil, nil, err
+	}
+	resumeCfg.Compile()
+	return &resumeCfg, envelope.Filter, nil
+}
 
-	return os.WriteFile(path, data, permissionutil.ConfigFilePermission)
+// ListResumeConfigs returns the names of every named resume slot currently
+// persisted under ~/.config/nuclei/resume.
+func ListResumeConfigs() ([]string, error) {
+	dir, err := resumeDir()
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".cfg" {
+			continue
+		}
+		names = append(names, strings.TrimSuffix(entry.Name(), ".cfg"))
+	}
+	return names, nil
+}
+
+// DeleteResumeConfig removes a named resume slot, implementing
+// -resume-delete.
+func DeleteResumeConfig(name string) error {
+	path, err := resumePathForName(name)
+	if err != nil {
+		return err
+	}
+	return os.Remove(path)
 }
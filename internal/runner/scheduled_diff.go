@@ -0,0 +1,34 @@
+package runner
+
+import (
+	"sync"
+
+	"github.com/projectdiscovery/nuclei/v3/pkg/output"
+)
+
+// diffingWriter wraps an output.Writer and additionally captures every
+// result it is asked to write, so a scheduled run can diff its findings
+// against the previous run via scheduler.DiffNew.
+type diffingWriter struct {
+	output.Writer
+
+	mu      sync.Mutex
+	results []*output.ResultEvent
+}
+
+// Write records event before forwarding it to the wrapped writer.
+func (w *diffingWriter) Write(event *output.ResultEvent) error {
+	w.mu.Lock()
+	w.results = append(w.results, event)
+	w.mu.Unlock()
+	return w.Writer.Write(event)
+}
+
+// Results returns every event captured so far.
+func (w *diffingWriter) Results() []*output.ResultEvent {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	results := make([]*output.ResultEvent, len(w.results))
+	copy(results, w.results)
+	return results
+}
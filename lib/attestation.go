@@ -0,0 +1,199 @@
+package nuclei
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"os"
+	"strconv"
+	"sync"
+
+	"github.com/projectdiscovery/nuclei/v3/pkg/output"
+	"github.com/projectdiscovery/nuclei/v3/pkg/templates/signer"
+)
+
+// inTotoStatementType is the in-toto v1.0 Statement `_type`.
+const inTotoStatementType = "https://in-toto.io/Statement/v1"
+
+// findingPredicateType identifies nuclei's finding predicate, tying a
+// matched target back to the signed template that found it.
+const findingPredicateType = "https://projectdiscovery.io/nuclei/finding/v1"
+
+// inTotoSubject is a single attested artifact: the matched target, digested
+// by its response body.
+type inTotoSubject struct {
+	Name   string            `json:"name"`
+	Digest map[string]string `json:"digest"`
+}
+
+// findingPredicate describes the signed template that produced a finding.
+type findingPredicate struct {
+	TemplateID       string   `json:"templateId"`
+	TemplateDigest   string   `json:"templateDigest,omitempty"`
+	SignerKeyID      string   `json:"signerKeyId,omitempty"`
+	TemplatePath     string   `json:"templatePath,omitempty"`
+	MatcherName      string   `json:"matcherName,omitempty"`
+	ExtractorResults []string `json:"extractorResults,omitempty"`
+}
+
+// inTotoStatement is an in-toto v1.0 Statement linking a matched target
+// (subject) to the template that found it (predicate).
+type inTotoStatement struct {
+	Type          string           `json:"_type"`
+	Subject       []inTotoSubject  `json:"subject"`
+	PredicateType string           `json:"predicateType"`
+	Predicate     findingPredicate `json:"predicate"`
+}
+
+// dsseEnvelope is a Dead Simple Signing Envelope wrapping a single signed
+// statement, per https://github.com/secure-systems-lab/dsse.
+type dsseEnvelope struct {
+	PayloadType string          `json:"payloadType"`
+	Payload     string          `json:"payload"`
+	Signatures  []dsseSignature `json:"signatures"`
+}
+
+type dsseSignature struct {
+	KeyID string `json:"keyid,omitempty"`
+	Sig   string `json:"sig"`
+}
+
+// AttestationOption configures the attestation sink constructed by
+// WithAttestationWriter.
+type AttestationOption func(*attestationSink)
+
+// WithAttestationSigner DSSE-envelopes and signs every statement using
+// backend, cryptographically tying each finding back to the signed
+// template that produced it. Without it, statements are written unsigned.
+func WithAttestationSigner(backend signer.SignerBackend) AttestationOption {
+	return func(s *attestationSink) {
+		s.signerBackend = backend
+	}
+}
+
+// attestationSink writes one in-toto statement per finding to an
+// io.Writer as newline-delimited JSON, optionally DSSE-signed.
+type attestationSink struct {
+	mu            sync.Mutex
+	writer        io.Writer
+	signerBackend signer.SignerBackend
+}
+
+// WithAttestationWriter emits, alongside every nuclei finding delivered to
+// ExecuteWithCallback, an in-toto v1.0 statement to w describing the
+// template that produced it, as newline-delimited JSON.
+func WithAttestationWriter(w io.Writer, opts ...AttestationOption) NucleiSDKOptions {
+	return func(e *NucleiEngine) error {
+		sink := &attestationSink{writer: w}
+		for _, opt := range opts {
+			opt(sink)
+		}
+		e.attestationSink = sink
+		e.resultCallbacks = append(e.resultCallbacks, sink.emit)
+		return nil
+	}
+}
+
+// dssePayloadType is the DSSE payloadType used for the PAE encoding as well
+// as the envelope itself, identifying the payload as an in-toto statement.
+const dssePayloadType = "application/vnd.in-toto+json"
+
+func (s *attestationSink) emit(event *output.ResultEvent) {
+	predicate := findingPredicate{
+		TemplateID:       event.TemplateID,
+		TemplatePath:     event.TemplatePath,
+		MatcherName:      event.MatcherName,
+		ExtractorResults: event.ExtractedResults,
+	}
+	if s.signerBackend != nil {
+		predicate.TemplateDigest = templateDigest(event.TemplatePath)
+		predicate.SignerKeyID = s.signerBackend.GetKeyID()
+	}
+
+	statement := inTotoStatement{
+		Type:          inTotoStatementType,
+		PredicateType: findingPredicateType,
+		Subject: []inTotoSubject{{
+			Name:   event.Matched,
+			Digest: map[string]string{"sha256": responseBodyDigest(event)},
+		}},
+		Predicate: predicate,
+	}
+
+	payload, err := json.Marshal(statement)
+	if err != nil {
+		return
+	}
+
+	var line []byte
+	if s.signerBackend != nil {
+		line, err = s.signAndEnvelope(payload)
+		if err != nil {
+			return
+		}
+	} else {
+		line = payload
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, _ = s.writer.Write(append(line, '\n'))
+}
+
+// preAuthenticationEncoding builds the DSSE PAE(payloadType, payload) byte
+// sequence that is actually signed, per the DSSE spec:
+// "DSSEv1" SP len(payloadType) SP payloadType SP len(payload) SP payload.
+func preAuthenticationEncoding(payloadType string, payload []byte) []byte {
+	var buf bytes.Buffer
+	buf.WriteString("DSSEv1 ")
+	buf.WriteString(strconv.Itoa(len(payloadType)))
+	buf.WriteString(" ")
+	buf.WriteString(payloadType)
+	buf.WriteString(" ")
+	buf.WriteString(strconv.Itoa(len(payload)))
+	buf.WriteString(" ")
+	buf.Write(payload)
+	return buf.Bytes()
+}
+
+func (s *attestationSink) signAndEnvelope(payload []byte) ([]byte, error) {
+	pae := preAuthenticationEncoding(dssePayloadType, payload)
+	digest := sha256.Sum256(pae)
+	sig, keyID, err := s.signerBackend.Sign(digest[:])
+	if err != nil {
+		return nil, err
+	}
+	envelope := dsseEnvelope{
+		PayloadType: dssePayloadType,
+		Payload:     base64.StdEncoding.EncodeToString(payload),
+		Signatures:  []dsseSignature{{KeyID: keyID, Sig: base64.StdEncoding.EncodeToString(sig)}},
+	}
+	return json.Marshal(envelope)
+}
+
+// templateDigest returns the hex SHA-256 of the signed template file at
+// path, so a finding's predicate can be tied back to the exact signed
+// template content that produced it. An empty string is returned if the
+// template can't be read.
+func templateDigest(path string) string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	return signer.TemplateDigest(data)
+}
+
+// responseBodyDigest returns the hex SHA-256 of the matched response body:
+// the part of event.Response after the first blank line ending the headers,
+// or the whole response when it has no such header block.
+func responseBodyDigest(event *output.ResultEvent) string {
+	body := []byte(event.Response)
+	if idx := bytes.Index(body, []byte("\r\n\r\n")); idx != -1 {
+		body = body[idx+4:]
+	}
+	sum := sha256.Sum256(body)
+	return hex.EncodeToString(sum[:])
+}
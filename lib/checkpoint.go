@@ -0,0 +1,150 @@
+package nuclei
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/projectdiscovery/nuclei/v3/pkg/input/provider"
+	"github.com/projectdiscovery/nuclei/v3/pkg/protocols/common/contextargs"
+)
+
+// engineCheckpointSchemaVersion guards the on-disk Checkpoint format so a
+// RestoreCheckpoint from an older/newer nuclei version fails loudly instead
+// of silently misbehaving.
+const engineCheckpointSchemaVersion = 1
+
+// engineCheckpoint is the compact on-disk format written by Checkpoint and
+// consumed by RestoreCheckpoint.
+type engineCheckpoint struct {
+	SchemaVersion int      `json:"schema_version"`
+	Completed     []string `json:"completed"`
+}
+
+// completedPairKey identifies a (template-id, target) pair for the
+// completed-pairs set tracked by Checkpoint/RestoreCheckpoint.
+func completedPairKey(templateID, target string) string {
+	return templateID + "|" + target
+}
+
+// recordCompletedTarget marks (templateID, target) as done for Checkpoint.
+// It's called once a target has been handed off for execution against
+// templateID, regardless of whether it produces a match, so a restored
+// checkpoint skips every pair already executed - not only the ones that
+// happened to match.
+func (e *NucleiEngine) recordCompletedTarget(templateID, target string) {
+	e.completedMu.Lock()
+	defer e.completedMu.Unlock()
+	if e.completedPairs == nil {
+		e.completedPairs = make(map[string]struct{})
+	}
+	e.completedPairs[completedPairKey(templateID, target)] = struct{}{}
+}
+
+// Pause stops the engine from dispatching new (template, target) pairs,
+// letting executions already in flight drain, so a long-running scan can be
+// safely checkpointed with Checkpoint.
+func (e *NucleiEngine) Pause() {
+	e.completedMu.Lock()
+	e.checkpointActive = true
+	e.completedMu.Unlock()
+	e.engine.Pause()
+}
+
+// Resume lets an engine paused with Pause resume dispatching new
+// (template, target) pairs.
+func (e *NucleiEngine) Resume() {
+	e.engine.Resume()
+}
+
+// Checkpoint serializes the set of (template-id, target) pairs completed so
+// far to w. Call after Pause so the completed set is stable. A later
+// RestoreCheckpoint on a fresh engine replays w to skip those pairs and
+// continue the scan from where this run left off.
+func (e *NucleiEngine) Checkpoint(w io.Writer) error {
+	e.completedMu.Lock()
+	completed := make([]string, 0, len(e.completedPairs))
+	for pair := range e.completedPairs {
+		completed = append(completed, pair)
+	}
+	e.completedMu.Unlock()
+
+	return json.NewEncoder(w).Encode(engineCheckpoint{
+		SchemaVersion: engineCheckpointSchemaVersion,
+		Completed:     completed,
+	})
+}
+
+// completedSkippingProvider wraps a provider.InputProvider and hides every
+// target already recorded in completedPairs for one specific template, so a
+// checkpoint restored with RestoreCheckpoint actually skips (template,
+// target) pairs already executed instead of only being loaded and ignored.
+type completedSkippingProvider struct {
+	provider.InputProvider
+
+	engine     *NucleiEngine
+	templateID string
+}
+
+// inputProviderSkippingCompleted returns e.inputProvider wrapped so Iterate
+// skips every target already completed for templateID.
+func (e *NucleiEngine) inputProviderSkippingCompleted(templateID string) provider.InputProvider {
+	return &completedSkippingProvider{InputProvider: e.inputProvider, engine: e, templateID: templateID}
+}
+
+func (p *completedSkippingProvider) isCompleted(target string) bool {
+	p.engine.completedMu.Lock()
+	defer p.engine.completedMu.Unlock()
+	_, done := p.engine.completedPairs[completedPairKey(p.templateID, target)]
+	return done
+}
+
+// Iterate calls callback for every target of the wrapped provider that
+// wasn't already completed for this template, and records each one as
+// completed once callback returns - independent of whether it matched -
+// so a checkpoint taken afterwards skips it on restore.
+func (p *completedSkippingProvider) Iterate(callback func(value *contextargs.MetaInput) bool) {
+	p.InputProvider.Iterate(func(value *contextargs.MetaInput) bool {
+		if p.isCompleted(value.Input) {
+			return true
+		}
+		cont := callback(value)
+		p.engine.recordCompletedTarget(p.templateID, value.Input)
+		return cont
+	})
+}
+
+// Count returns the number of targets not yet completed for this template.
+func (p *completedSkippingProvider) Count() int64 {
+	var count int64
+	p.InputProvider.Iterate(func(value *contextargs.MetaInput) bool {
+		if !p.isCompleted(value.Input) {
+			count++
+		}
+		return true
+	})
+	return count
+}
+
+// RestoreCheckpoint loads a checkpoint written by Checkpoint so the next
+// ExecuteWithCallback call skips already-completed (template-id, target)
+// pairs instead of re-running them.
+func (e *NucleiEngine) RestoreCheckpoint(r io.Reader) error {
+	var checkpoint engineCheckpoint
+	if err := json.NewDecoder(r).Decode(&checkpoint); err != nil {
+		return err
+	}
+	if checkpoint.SchemaVersion != engineCheckpointSchemaVersion {
+		return ErrCheckpointSchemaMismatch.Msgf(checkpoint.SchemaVersion)
+	}
+
+	e.completedMu.Lock()
+	defer e.completedMu.Unlock()
+	if e.completedPairs == nil {
+		e.completedPairs = make(map[string]struct{}, len(checkpoint.Completed))
+	}
+	for _, pair := range checkpoint.Completed {
+		e.completedPairs[pair] = struct{}{}
+	}
+	e.checkpointActive = true
+	return nil
+}
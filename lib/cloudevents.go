@@ -0,0 +1,123 @@
+package nuclei
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/projectdiscovery/gologger"
+	"github.com/projectdiscovery/nuclei/v3/pkg/output"
+	"github.com/projectdiscovery/retryablehttp-go"
+)
+
+// cloudEventsFindingType is the CloudEvents `type` attribute set on every
+// nuclei finding event emitted via WithCloudEventsSink.
+const cloudEventsFindingType = "io.projectdiscovery.nuclei.finding.v1"
+
+// CloudEvent is a CloudEvents v1.0 envelope carrying a single nuclei
+// finding, suitable for SIEMs, Knative sinks or Tekton notifiers that
+// consume CloudEvents rather than scraping JSON logs.
+type CloudEvent struct {
+	SpecVersion     string              `json:"specversion"`
+	ID              string              `json:"id"`
+	Source          string              `json:"source"`
+	Type            string              `json:"type"`
+	Subject         string              `json:"subject"`
+	Time            time.Time           `json:"time"`
+	DataContentType string              `json:"datacontenttype"`
+	Data            *output.ResultEvent `json:"data"`
+}
+
+// CESinkOption configures the CloudEvents sink constructed by
+// WithCloudEventsSink.
+type CESinkOption func(*cloudEventsSink)
+
+// WithCESource overrides the CloudEvents `source` attribute, which defaults
+// to the engine instance's binary name.
+func WithCESource(source string) CESinkOption {
+	return func(s *cloudEventsSink) {
+		s.source = source
+	}
+}
+
+// WithCEHTTPClient overrides the retryablehttp client used to deliver
+// events, e.g. to route through a Kafka/NATS bridge fronted by HTTP.
+func WithCEHTTPClient(client *retryablehttp.Client) CESinkOption {
+	return func(s *cloudEventsSink) {
+		s.client = client
+	}
+}
+
+// cloudEventsSink converts nuclei ResultEvents into CloudEvents v1.0
+// envelopes and delivers them over HTTP.
+type cloudEventsSink struct {
+	url    string
+	source string
+	client *retryablehttp.Client
+}
+
+// WithCloudEventsSink registers a result callback that converts every
+// nuclei finding delivered to ExecuteWithCallback into a CloudEvents v1.0
+// envelope and ships it to url.
+func WithCloudEventsSink(url string, opts ...CESinkOption) NucleiSDKOptions {
+	return func(e *NucleiEngine) error {
+		sink := &cloudEventsSink{
+			url:    url,
+			source: "nuclei",
+			client: retryablehttp.NewClient(retryablehttp.DefaultOptionsSingle),
+		}
+		for _, opt := range opts {
+			opt(sink)
+		}
+		e.ceSink = sink
+		e.resultCallbacks = append(e.resultCallbacks, sink.emit)
+		return nil
+	}
+}
+
+func (s *cloudEventsSink) emit(event *output.ResultEvent) {
+	ce := CloudEvent{
+		SpecVersion:     "1.0",
+		ID:              cloudEventID(event),
+		Source:          s.source,
+		Type:            cloudEventsFindingType,
+		Subject:         fmt.Sprintf("%s@%s", event.TemplateID, event.Matched),
+		Time:            time.Now(),
+		DataContentType: "application/json",
+		Data:            event,
+	}
+	data, err := json.Marshal(ce)
+	if err != nil {
+		return
+	}
+	req, err := retryablehttp.NewRequest(http.MethodPost, s.url, bytes.NewReader(data))
+	if err != nil {
+		return
+	}
+	req.Header.Set("Content-Type", "application/cloudevents+json")
+	resp, err := s.client.Do(req)
+	if err != nil {
+		gologger.Verbose().Msgf("Could not deliver CloudEvent to %s: %s\n", s.url, err)
+		return
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		gologger.Verbose().Msgf("Could not deliver CloudEvent to %s: unexpected status %s\n", s.url, resp.Status)
+	}
+}
+
+// cloudEventID deterministically derives the CloudEvents `id` from the
+// fields that make a finding unique, so redelivery of the same finding
+// produces the same id.
+func cloudEventID(event *output.ResultEvent) string {
+	hasher := sha256.New()
+	hasher.Write([]byte(event.TemplateID))
+	hasher.Write([]byte(event.Matched))
+	hasher.Write([]byte(strings.Join(event.ExtractedResults, "\x00")))
+	return hex.EncodeToString(hasher.Sum(nil))
+}
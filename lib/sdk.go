@@ -4,6 +4,7 @@ import (
 	"bufio"
 	"bytes"
 	"io"
+	"sync"
 
 	"github.com/projectdiscovery/nuclei/v3/pkg/authprovider"
 	"github.com/projectdiscovery/nuclei/v3/pkg/catalog/disk"
@@ -39,6 +40,8 @@ var (
 	ErrNoTargetsAvailable = errorutil.New("No targets available")
 	// ErrOptionsNotSupported is returned when an option is not supported in thread safe mode
 	ErrOptionsNotSupported = errorutil.NewWithFmt("Option %v not supported in thread safe mode")
+	// ErrCheckpointSchemaMismatch is returned when RestoreCheckpoint is given a checkpoint written by an incompatible nuclei version
+	ErrCheckpointSchemaMismatch = errorutil.NewWithFmt("checkpoint schema version %v is not supported")
 )
 
 type engineMode uint
@@ -57,6 +60,12 @@ type NucleiEngine struct {
 	disableTemplatesAutoUpgrade bool
 	enableStats                 bool
 	onUpdateAvailableCallback   func(newVersion string)
+	ceSink                      *cloudEventsSink
+	attestationSink             *attestationSink
+	signerBackend               signer.SignerBackend
+	completedMu                 sync.Mutex
+	completedPairs              map[string]struct{}
+	checkpointActive            bool
 
 	// ready-status fields
 	templatesLoaded bool
@@ -156,10 +165,26 @@ func (e *NucleiEngine) GetExecuterOptions() *protocols.ExecutorOptions {
 // ParseTemplate parses a template from given data
 // template verification status can be accessed from template.Verified
 func (e *NucleiEngine) ParseTemplate(data []byte) (*templates.Template, error) {
-	return templates.ParseTemplateFromReader(bytes.NewReader(data), nil, e.executerOpts)
+	tmpl, err := templates.ParseTemplateFromReader(bytes.NewReader(data), nil, e.executerOpts)
+	if err != nil {
+		return tmpl, err
+	}
+	// TemplateSigner only knows its own local-key signature format, so a
+	// template signed with a configured SignerBackend (e.g. KMS) instead
+	// comes back unverified from it - check our own signature block before
+	// giving up on it.
+	if tmpl != nil && !tmpl.Verified && e.signerBackend != nil {
+		if signer.VerifyWithBackend(e.signerBackend, data) == nil {
+			tmpl.Verified = true
+		}
+	}
+	return tmpl, nil
 }
 
-// SignTemplate signs the tempalate using given signer
+// SignTemplate signs the template using tmplSigner if given, falling back
+// to the engine's configured SignerBackend (see WithSignerBackend) when
+// tmplSigner is nil, e.g. to sign with a KMS-backed key instead of a local
+// one.
 func (e *NucleiEngine) SignTemplate(tmplSigner *signer.TemplateSigner, data []byte) ([]byte, error) {
 	tmpl, err := e.ParseTemplate(data)
 	if err != nil {
@@ -172,13 +197,19 @@ func (e *NucleiEngine) SignTemplate(tmplSigner *signer.TemplateSigner, data []by
 	if len(tmpl.Workflows) > 0 {
 		return data, templates.ErrNotATemplate
 	}
-	signatureData, err := tmplSigner.Sign(data, tmpl)
-	if err != nil {
-		return data, err
+	if tmplSigner != nil {
+		signatureData, err := tmplSigner.Sign(data, tmpl)
+		if err != nil {
+			return data, err
+		}
+		buff := bytes.NewBuffer(signer.RemoveSignatureFromData(data))
+		buff.WriteString("\n" + signatureData)
+		return buff.Bytes(), nil
+	}
+	if e.signerBackend != nil {
+		return signer.SignWithBackend(e.signerBackend, data)
 	}
-	buff := bytes.NewBuffer(signer.RemoveSignatureFromData(data))
-	buff.WriteString("\n" + signatureData)
-	return buff.Bytes(), err
+	return data, errorutil.New("no template signer or signer backend configured")
 }
 
 // Close all resources used by nuclei engine
@@ -188,21 +219,35 @@ func (e *NucleiEngine) Close() {
 	e.customWriter.Close()
 	e.hostErrCache.Close()
 	e.executerOpts.RateLimiter.Stop()
+	if e.ceSink != nil {
+		e.ceSink.client.HTTPClient.CloseIdleConnections()
+	}
 }
 
 // ExecuteWithCallback executes templates on targets and calls callback on each result(only if results are found)
+//
+// Normally all templates are dispatched to the engine as a single batch, so
+// cross-template request clustering and inter-template parallelism work the
+// same as they always have. Once Pause or RestoreCheckpoint has been used,
+// e.checkpointActive switches this to dispatch templates to the engine one
+// at a time instead: the engine blocks in Engine.WaitIfPaused before each
+// template, and any (template, target) pair already present in
+// e.completedPairs is skipped instead of re-run. The per-template path costs
+// throughput, so it only applies to callers that actually opted into
+// checkpointing.
 func (e *NucleiEngine) ExecuteWithCallback(callback ...func(event *output.ResultEvent)) error {
 	if !e.templatesLoaded {
 		_ = e.LoadAllTemplates()
 	}
-	if len(e.store.Templates()) == 0 && len(e.store.Workflows()) == 0 {
+	templateList, workflows := e.store.Templates(), e.store.Workflows()
+	if len(templateList) == 0 && len(workflows) == 0 {
 		return ErrNoTemplatesAvailable
 	}
 	if e.inputProvider.Count() == 0 {
 		return ErrNoTargetsAvailable
 	}
 
-	filtered := []func(event *output.ResultEvent){}
+	filtered := make([]func(event *output.ResultEvent), 0, len(callback))
 	for _, callback := range callback {
 		if callback != nil {
 			filtered = append(filtered, callback)
@@ -210,8 +255,34 @@ func (e *NucleiEngine) ExecuteWithCallback(callback ...func(event *output.Result
 	}
 	e.resultCallbacks = append(e.resultCallbacks, filtered...)
 
-	_ = e.engine.ExecuteScanWithOpts(e.store.Templates(), e.inputProvider, false)
-	defer e.engine.WorkPool().Wait()
+	// Workflows run as a single batch: a workflow's own logic dispatches
+	// templates within itself, so it can't be driven one (template, target)
+	// pair at a time from here.
+	if len(workflows) > 0 {
+		e.engine.WaitIfPaused()
+		_ = e.engine.ExecuteScanWithOpts(workflows, e.inputProvider, false)
+	}
+
+	e.completedMu.Lock()
+	checkpointActive := e.checkpointActive
+	e.completedMu.Unlock()
+
+	if !checkpointActive {
+		e.engine.WaitIfPaused()
+		_ = e.engine.ExecuteScanWithOpts(templateList, e.inputProvider, false)
+		e.engine.WorkPool().Wait()
+		return nil
+	}
+
+	for _, tpl := range templateList {
+		e.engine.WaitIfPaused()
+		remaining := e.inputProviderSkippingCompleted(tpl.ID)
+		if remaining.Count() == 0 {
+			continue
+		}
+		_ = e.engine.ExecuteScanWithOpts([]*templates.Template{tpl}, remaining, false)
+	}
+	e.engine.WorkPool().Wait()
 	return nil
 }
 
@@ -0,0 +1,17 @@
+package nuclei
+
+import (
+	"github.com/projectdiscovery/nuclei/v3/pkg/templates/signer"
+)
+
+// WithSignerBackend configures the engine to sign/verify templates using
+// backend (e.g. an AWS or GCP KMS-backed key) instead of a local
+// TemplateSigner. SignTemplate uses it when called without a tmplSigner,
+// and ParseTemplate uses it to verify templates TemplateSigner's own
+// verification left unverified.
+func WithSignerBackend(backend signer.SignerBackend) NucleiSDKOptions {
+	return func(e *NucleiEngine) error {
+		e.signerBackend = backend
+		return nil
+	}
+}
@@ -0,0 +1,321 @@
+// Package autoupdate implements a safe, reversible update flow for the
+// nuclei engine and nuclei-templates: a signed manifest is fetched, the new
+// templates are staged alongside the current installation, validated with a
+// dry-run, and only then swapped in atomically - with the previous version
+// kept around for a one-command rollback.
+package autoupdate
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/projectdiscovery/gologger"
+)
+
+// Channel selects which release stream to update from.
+type Channel string
+
+const (
+	Stable Channel = "stable"
+	Beta   Channel = "beta"
+	Canary Channel = "canary"
+)
+
+// stagingSuffix/rollbackSuffix name the atomically-swapped directories kept
+// alongside TemplatesDirectory.
+const (
+	stagingSuffix  = ".next"
+	rollbackSuffix = ".prev"
+)
+
+// Manifest describes the signed, published set of versions for a channel.
+type Manifest struct {
+	Channel         Channel           `json:"channel"`
+	EngineVersion   string            `json:"engine_version"`
+	TemplateVersion string            `json:"template_version"`
+	TemplatesURL    string            `json:"templates_url"`
+	TemplatesSHA256 string            `json:"templates_sha256"`
+	Signature       string            `json:"signature"`
+	Extra           map[string]string `json:"extra,omitempty"`
+}
+
+// Verifier checks a manifest's Sigstore-style signature before it is acted
+// upon.
+type Verifier interface {
+	Verify(manifest Manifest) error
+}
+
+// TemplateValidator validates a staged templates directory before it is
+// swapped in. It's satisfied by loader.Store.ValidateTemplates, adapted by
+// the caller since autoupdate has no dependency on the loader package.
+type TemplateValidator func(templatesDir string) error
+
+// Fetcher retrieves the manifest and the templates archive for channel.
+type Fetcher interface {
+	FetchManifest(ctx context.Context, channel Channel) (Manifest, error)
+	FetchTemplates(ctx context.Context, manifest Manifest) (io.ReadCloser, error)
+}
+
+// Options configures an Updater.
+type Options struct {
+	TemplatesDirectory string
+	Channel            Channel
+	Fetcher            Fetcher
+	Verifier           Verifier
+	Validate           TemplateValidator
+}
+
+// Updater performs staged, verified, reversible updates of nuclei-templates.
+type Updater struct {
+	options Options
+}
+
+// New creates an Updater. Channel defaults to Stable if unset.
+func New(options Options) (*Updater, error) {
+	if options.TemplatesDirectory == "" {
+		return nil, errors.New("templates directory is required")
+	}
+	if options.Channel == "" {
+		options.Channel = Stable
+	}
+	if options.Fetcher == nil {
+		return nil, errors.New("fetcher is required")
+	}
+	return &Updater{options: options}, nil
+}
+
+// CheckForUpdates fetches the manifest for the configured channel and, if a
+// newer template version is published, performs a staged update. It returns
+// the manifest that was applied (or the currently-installed one, if there
+// was nothing to do).
+func (u *Updater) CheckForUpdates(ctx context.Context) (Manifest, error) {
+	manifest, err := u.options.Fetcher.FetchManifest(ctx, u.options.Channel)
+	if err != nil {
+		return Manifest{}, fmt.Errorf("could not fetch update manifest: %w", err)
+	}
+	if u.options.Verifier != nil {
+		if err := u.options.Verifier.Verify(manifest); err != nil {
+			return Manifest{}, fmt.Errorf("manifest signature verification failed: %w", err)
+		}
+	}
+
+	currentVersion, _ := os.ReadFile(filepath.Join(u.options.TemplatesDirectory, ".version"))
+	if string(currentVersion) == manifest.TemplateVersion {
+		return manifest, nil
+	}
+
+	if err := u.stageAndSwap(ctx, manifest); err != nil {
+		return Manifest{}, err
+	}
+	return manifest, nil
+}
+
+func (u *Updater) stageAndSwap(ctx context.Context, manifest Manifest) error {
+	stagingDir := u.options.TemplatesDirectory + stagingSuffix
+	if err := os.RemoveAll(stagingDir); err != nil {
+		return fmt.Errorf("could not clean staging directory: %w", err)
+	}
+
+	body, err := u.options.Fetcher.FetchTemplates(ctx, manifest)
+	if err != nil {
+		return fmt.Errorf("could not fetch templates: %w", err)
+	}
+	defer body.Close()
+
+	digest, err := extractTo(stagingDir, body)
+	if err != nil {
+		return fmt.Errorf("could not stage templates: %w", err)
+	}
+	if manifest.TemplatesSHA256 != "" && digest != manifest.TemplatesSHA256 {
+		_ = os.RemoveAll(stagingDir)
+		return fmt.Errorf("templates digest mismatch: expected %s got %s", manifest.TemplatesSHA256, digest)
+	}
+
+	if u.options.Validate != nil {
+		if err := u.options.Validate(stagingDir); err != nil {
+			_ = os.RemoveAll(stagingDir)
+			return fmt.Errorf("staged templates failed validation: %w", err)
+		}
+	}
+
+	if err := os.WriteFile(filepath.Join(stagingDir, ".version"), []byte(manifest.TemplateVersion), 0644); err != nil {
+		return fmt.Errorf("could not write staged version marker: %w", err)
+	}
+
+	rollbackDir := u.options.TemplatesDirectory + rollbackSuffix
+	_ = os.RemoveAll(rollbackDir)
+	if _, err := os.Stat(u.options.TemplatesDirectory); err == nil {
+		if err := os.Rename(u.options.TemplatesDirectory, rollbackDir); err != nil {
+			return fmt.Errorf("could not preserve previous templates for rollback: %w", err)
+		}
+	}
+	if err := os.Rename(stagingDir, u.options.TemplatesDirectory); err != nil {
+		// best-effort restore of the previous install
+		_ = os.Rename(rollbackDir, u.options.TemplatesDirectory)
+		return fmt.Errorf("could not swap in staged templates: %w", err)
+	}
+
+	gologger.Info().Msgf("Updated nuclei-templates to version %s (channel: %s)\n", manifest.TemplateVersion, u.options.Channel)
+	return nil
+}
+
+// Rollback restores the previous templates installation saved by the last
+// successful update. It is the implementation behind `-rollback-templates`.
+func (u *Updater) Rollback() error {
+	rollbackDir := u.options.TemplatesDirectory + rollbackSuffix
+	if _, err := os.Stat(rollbackDir); err != nil {
+		return fmt.Errorf("no previous templates installation available to roll back to: %w", err)
+	}
+
+	currentDir := u.options.TemplatesDirectory
+	discardedDir := currentDir + ".rolled-back"
+	_ = os.RemoveAll(discardedDir)
+	if err := os.Rename(currentDir, discardedDir); err != nil {
+		return fmt.Errorf("could not move aside current templates: %w", err)
+	}
+	if err := os.Rename(rollbackDir, currentDir); err != nil {
+		_ = os.Rename(discardedDir, currentDir)
+		return fmt.Errorf("could not restore previous templates: %w", err)
+	}
+	_ = os.RemoveAll(discardedDir)
+
+	gologger.Info().Msgf("Rolled back nuclei-templates to the previous installation\n")
+	return nil
+}
+
+// extractTo extracts the gzipped tarball read from body into dir and returns
+// the SHA-256 digest of the raw (compressed) stream, so the caller can check
+// it against Manifest.TemplatesSHA256 without a second pass over body.
+func extractTo(dir string, body io.Reader) (string, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+
+	hasher := sha256.New()
+	tee := io.TeeReader(body, hasher)
+
+	gzReader, err := gzip.NewReader(tee)
+	if err != nil {
+		return "", fmt.Errorf("could not open templates archive: %w", err)
+	}
+	defer gzReader.Close()
+
+	tarReader := tar.NewReader(gzReader)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", fmt.Errorf("could not read templates archive: %w", err)
+		}
+
+		target, err := sanitizeExtractPath(dir, header.Name)
+		if err != nil {
+			return "", err
+		}
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return "", err
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+				return "", err
+			}
+			if err := writeExtractedFile(target, tarReader, header.FileInfo().Mode()); err != nil {
+				return "", err
+			}
+		}
+	}
+
+	// drain any remaining bytes (e.g. gzip trailer) so the digest covers the
+	// whole stream exactly as published in the manifest.
+	if _, err := io.Copy(io.Discard, tee); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hasher.Sum(nil)), nil
+}
+
+// sanitizeExtractPath joins dir and name, rejecting entries (via "../" or an
+// absolute path) that would extract outside dir.
+func sanitizeExtractPath(dir, name string) (string, error) {
+	target := filepath.Join(dir, name)
+	if target != dir && !strings.HasPrefix(target, dir+string(os.PathSeparator)) {
+		return "", fmt.Errorf("templates archive entry escapes staging directory: %s", name)
+	}
+	return target, nil
+}
+
+func writeExtractedFile(target string, r io.Reader, mode os.FileMode) error {
+	file, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, mode)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	_, err = io.Copy(file, r)
+	return err
+}
+
+// HTTPFetcher is the default Fetcher, retrieving the manifest and templates
+// archive over HTTPS.
+type HTTPFetcher struct {
+	ManifestURLFor func(channel Channel) string
+	Client         *http.Client
+}
+
+func (f *HTTPFetcher) httpClient() *http.Client {
+	if f.Client != nil {
+		return f.Client
+	}
+	return http.DefaultClient
+}
+
+func (f *HTTPFetcher) FetchManifest(ctx context.Context, channel Channel) (Manifest, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, f.ManifestURLFor(channel), nil)
+	if err != nil {
+		return Manifest{}, err
+	}
+	resp, err := f.httpClient().Do(req)
+	if err != nil {
+		return Manifest{}, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return Manifest{}, fmt.Errorf("unexpected status fetching manifest: %s", resp.Status)
+	}
+
+	var manifest Manifest
+	if err := json.NewDecoder(resp.Body).Decode(&manifest); err != nil {
+		return Manifest{}, err
+	}
+	return manifest, nil
+}
+
+func (f *HTTPFetcher) FetchTemplates(ctx context.Context, manifest Manifest) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, manifest.TemplatesURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := f.httpClient().Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("unexpected status fetching templates: %s", resp.Status)
+	}
+	return resp.Body, nil
+}
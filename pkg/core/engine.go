@@ -1,6 +1,8 @@
 package core
 
 import (
+	"sync"
+
 	"github.com/projectdiscovery/nuclei/v3/pkg/output"
 	"github.com/projectdiscovery/nuclei/v3/pkg/protocols"
 	"github.com/projectdiscovery/nuclei/v3/pkg/types"
@@ -19,6 +21,10 @@ type Engine struct {
 	options      *types.Options
 	executerOpts protocols.ExecutorOptions
 	Callback     func(*output.ResultEvent) // Executed on results
+
+	pauseMu   sync.Mutex
+	pauseCond *sync.Cond
+	paused    bool
 }
 
 // New returns a new Engine instance
@@ -26,6 +32,7 @@ func New(options *types.Options) *Engine {
 	engine := &Engine{
 		options: options,
 	}
+	engine.pauseCond = sync.NewCond(&engine.pauseMu)
 	return engine
 }
 
@@ -55,3 +62,42 @@ func (e *Engine) ExecuterOptions() protocols.ExecutorOptions {
 func (e *Engine) WorkPool() *WorkPool {
 	return e.workPool
 }
+
+// Pause stops WaitIfPaused callers from returning, so a caller driving the
+// engine (template, target) batch by batch stops handing off any more work,
+// letting work already in flight drain. Used to checkpoint a long-running
+// scan and safely suspend it.
+func (e *Engine) Pause() {
+	e.pauseMu.Lock()
+	e.paused = true
+	e.pauseMu.Unlock()
+}
+
+// Resume wakes every goroutine blocked in WaitIfPaused, letting a paused
+// engine resume dispatching new (template, target) batches.
+func (e *Engine) Resume() {
+	e.pauseMu.Lock()
+	e.paused = false
+	e.pauseMu.Unlock()
+	e.pauseCond.Broadcast()
+}
+
+// Paused reports whether the engine is currently paused.
+func (e *Engine) Paused() bool {
+	e.pauseMu.Lock()
+	defer e.pauseMu.Unlock()
+	return e.paused
+}
+
+// WaitIfPaused blocks the calling goroutine while the engine is paused. A
+// caller that drives the engine one (template, target) batch at a time -
+// NucleiEngine.ExecuteWithCallback does, between templates - is expected to
+// call this before dispatching each batch, so Pause actually stops new work
+// instead of only flipping a flag nothing reads.
+func (e *Engine) WaitIfPaused() {
+	e.pauseMu.Lock()
+	defer e.pauseMu.Unlock()
+	for e.paused {
+		e.pauseCond.Wait()
+	}
+}
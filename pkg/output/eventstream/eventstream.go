@@ -0,0 +1,253 @@
+// Package eventstream emits a structured, machine-consumable record of a
+// scan's lifecycle as newline-delimited JSON, for SIEMs and orchestrators
+// that would otherwise have to scrape human-readable gologger output to track
+// scan progress. The runner records the template_load, per_input_dispatch
+// and interactsh_stop phases plus the final scan_summary; http probe,
+// uncover expansion and interactsh start are not recorded.
+package eventstream
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/projectdiscovery/gologger"
+)
+
+// Event is a single lifecycle record.
+type Event struct {
+	Event      string    `json:"event"`
+	ScanID     string    `json:"scan_id"`
+	TemplateID string    `json:"template_id,omitempty"`
+	Target     string    `json:"target,omitempty"`
+	Phase      string    `json:"phase"`
+	DurationMS int64     `json:"duration_ms,omitempty"`
+	Dispatched int64     `json:"dispatched,omitempty"`
+	Error      string    `json:"error,omitempty"`
+	Timestamp  time.Time `json:"timestamp"`
+}
+
+// PhaseInputDispatch is the Record phase for dispatching the loaded
+// templates against the input set. The runner records it once per
+// enumeration, without a template or target, not once per (template, target)
+// pair. It's the only phase that counts toward the scan_summary "dispatched"
+// total, since Record is also called for other lifecycle phases
+// (template_load, interactsh_stop, ...) that aren't dispatches themselves.
+const PhaseInputDispatch = "per_input_dispatch"
+
+// EventSink consumes lifecycle events. Sink implementations must be safe for
+// concurrent use.
+type EventSink interface {
+	Emit(event Event)
+	Close() error
+}
+
+// fileSink appends newline-delimited JSON events to a file.
+type fileSink struct {
+	mu   sync.Mutex
+	file io.WriteCloser
+}
+
+// NewFileSink opens (or creates) path for appending events.
+func NewFileSink(path string) (EventSink, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, err
+	}
+	return &fileSink{file: file}, nil
+}
+
+func (f *fileSink) Emit(event Event) {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	_, _ = f.file.Write(append(data, '\n'))
+}
+
+func (f *fileSink) Close() error {
+	return f.file.Close()
+}
+
+// httpSink posts each event as a JSON body to a configured URL. Delivery is
+// best-effort; failures are logged, never fatal to the scan.
+type httpSink struct {
+	url    string
+	client *http.Client
+}
+
+// NewHTTPSink posts events to url as they are emitted.
+func NewHTTPSink(url string) EventSink {
+	return &httpSink{url: url, client: &http.Client{Timeout: 5 * time.Second}}
+}
+
+func (h *httpSink) Emit(event Event) {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return
+	}
+	resp, err := h.client.Post(h.url, "application/json", strings.NewReader(string(data)))
+	if err != nil {
+		gologger.Verbose().Msgf("Could not deliver event to %s: %s\n", h.url, err)
+		return
+	}
+	_ = resp.Body.Close()
+}
+
+func (h *httpSink) Close() error {
+	return nil
+}
+
+// multiSink fans out Emit/Close to every configured sink.
+type multiSink struct {
+	sinks []EventSink
+}
+
+func (m *multiSink) Emit(event Event) {
+	for _, sink := range m.sinks {
+		sink.Emit(event)
+	}
+}
+
+func (m *multiSink) Close() error {
+	var firstErr error
+	for _, sink := range m.sinks {
+		if err := sink.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// NewFromOptions builds a sink from the configured events file/URL. It
+// returns nil if neither is set, in which case Recorder calls are no-ops.
+func NewFromOptions(eventsFile, eventsURL string) (EventSink, error) {
+	var sinks []EventSink
+	if eventsFile != "" {
+		sink, err := NewFileSink(eventsFile)
+		if err != nil {
+			return nil, err
+		}
+		sinks = append(sinks, sink)
+	}
+	if eventsURL != "" {
+		sinks = append(sinks, NewHTTPSink(eventsURL))
+	}
+	if len(sinks) == 0 {
+		return nil, nil
+	}
+	return &multiSink{sinks: sinks}, nil
+}
+
+// Recorder wraps an EventSink with convenience phase-specific emit helpers
+// and scan-wide counters used to build the final scan_summary event.
+type Recorder struct {
+	sink   EventSink
+	scanID string
+
+	mu            sync.Mutex
+	dispatched    uint64
+	errors        uint64
+	errorTaxonomy map[string]uint64
+}
+
+// NewRecorder wraps sink, which may be nil (all Record calls become no-ops).
+func NewRecorder(sink EventSink, scanID string) *Recorder {
+	return &Recorder{sink: sink, scanID: scanID, errorTaxonomy: make(map[string]uint64)}
+}
+
+// Record emits an event for phase, optionally scoped to a templateID/target,
+// taking duration and err into account.
+func (r *Recorder) Record(phase, templateID, target string, duration time.Duration, err error) {
+	if r == nil || r.sink == nil {
+		return
+	}
+	event := Event{
+		Event:      "scan_lifecycle",
+		ScanID:     r.scanID,
+		TemplateID: templateID,
+		Target:     target,
+		Phase:      phase,
+		DurationMS: duration.Milliseconds(),
+		Timestamp:  time.Now(),
+	}
+	if err != nil {
+		event.Error = err.Error()
+	}
+	r.sink.Emit(event)
+
+	r.mu.Lock()
+	if phase == PhaseInputDispatch {
+		r.dispatched++
+	}
+	if err != nil {
+		r.errors++
+		r.errorTaxonomy[classifyError(err)]++
+	}
+	r.mu.Unlock()
+}
+
+// Summary emits a final scan_summary event with aggregate counts and error
+// taxonomy. It is safe to call exactly once, typically from Runner.Close(),
+// even on cancellation.
+func (r *Recorder) Summary() {
+	if r == nil || r.sink == nil {
+		return
+	}
+	r.mu.Lock()
+	dispatched := r.dispatched
+	taxonomy := make(map[string]uint64, len(r.errorTaxonomy))
+	for category, count := range r.errorTaxonomy {
+		taxonomy[category] = count
+	}
+	r.mu.Unlock()
+
+	r.sink.Emit(Event{
+		Event:      "scan_summary",
+		ScanID:     r.scanID,
+		Phase:      "scan_complete",
+		Dispatched: int64(dispatched),
+		Error:      summaryErrorTaxonomy(taxonomy),
+		Timestamp:  time.Now(),
+	})
+	_ = r.sink.Close()
+}
+
+// summaryErrorTaxonomy renders the per-category error counts accumulated by
+// Record as a single "category=count,category=count" string, sorted by
+// category name so the output is stable across runs.
+func summaryErrorTaxonomy(taxonomy map[string]uint64) string {
+	if len(taxonomy) == 0 {
+		return ""
+	}
+	categories := make([]string, 0, len(taxonomy))
+	for category := range taxonomy {
+		categories = append(categories, category)
+	}
+	sort.Strings(categories)
+
+	parts := make([]string, 0, len(categories))
+	for _, category := range categories {
+		parts = append(parts, category+"="+strconv.FormatUint(taxonomy[category], 10))
+	}
+	return strings.Join(parts, ",")
+}
+
+func classifyError(err error) string {
+	switch {
+	case strings.Contains(err.Error(), "timeout"):
+		return "timeout"
+	case strings.Contains(err.Error(), "refused"):
+		return "connection_refused"
+	default:
+		return "other"
+	}
+}
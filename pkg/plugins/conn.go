@@ -0,0 +1,19 @@
+package plugins
+
+import "io"
+
+// pluginConn adapts a child process's stdout/stdin pipes into the single
+// io.ReadWriteCloser that net/rpc/jsonrpc expects for its RPC transport.
+type pluginConn struct {
+	io.ReadCloser
+	io.WriteCloser
+}
+
+func (c *pluginConn) Close() error {
+	writeErr := c.WriteCloser.Close()
+	readErr := c.ReadCloser.Close()
+	if writeErr != nil {
+		return writeErr
+	}
+	return readErr
+}
@@ -0,0 +1,184 @@
+package plugins
+
+import (
+	"fmt"
+	"net/rpc"
+	"net/rpc/jsonrpc"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sync"
+
+	"github.com/projectdiscovery/gologger"
+	"gopkg.in/yaml.v3"
+)
+
+// loadedPlugin tracks a running plugin process and its RPC client.
+type loadedPlugin struct {
+	manifest Manifest
+	cmd      *exec.Cmd
+	client   *rpc.Client
+}
+
+// Manager discovers, launches and tracks the lifecycle of protocol plugins.
+// A Runner owns exactly one Manager and must call Close on shutdown so every
+// spawned plugin process is terminated.
+type Manager struct {
+	pluginsDir string
+	verifier   SignatureVerifier
+
+	mu      sync.Mutex
+	plugins map[string]*loadedPlugin
+}
+
+// SignatureVerifier verifies a plugin binary's signature before it is
+// launched. It is satisfied by Ed25519Verifier; the template-signing
+// backends do not implement it.
+type SignatureVerifier interface {
+	Verify(data []byte, signature string) error
+}
+
+// NewManager creates a plugin Manager rooted at pluginsDir (typically
+// ${NUCLEI_HOME}/plugins). verifier must be non-nil for any plugin to
+// actually load: a plugin binary is an arbitrary child process, so the
+// Manager fails closed and refuses to spawn anything rather than silently
+// running unverified plugins when no verifier is configured.
+func NewManager(pluginsDir string, verifier SignatureVerifier) *Manager {
+	return &Manager{
+		pluginsDir: pluginsDir,
+		verifier:   verifier,
+		plugins:    make(map[string]*loadedPlugin),
+	}
+}
+
+// Discover walks pluginsDir and returns the manifest of every plugin found,
+// without launching anything.
+func (m *Manager) Discover() ([]Manifest, error) {
+	entries, err := os.ReadDir(m.pluginsDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var manifests []Manifest
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		manifestPath := filepath.Join(m.pluginsDir, entry.Name(), "plugin.yaml")
+		data, err := os.ReadFile(manifestPath)
+		if err != nil {
+			continue
+		}
+		var manifest Manifest
+		if err := yaml.Unmarshal(data, &manifest); err != nil {
+			gologger.Warning().Msgf("Could not parse plugin manifest %s: %s\n", manifestPath, err)
+			continue
+		}
+		manifest.Dir = entry.Name()
+		manifests = append(manifests, manifest)
+	}
+	return manifests, nil
+}
+
+// LoadAll discovers every plugin under pluginsDir, verifies its signature
+// and spawns it as a child process.
+func (m *Manager) LoadAll() error {
+	manifests, err := m.Discover()
+	if err != nil {
+		return err
+	}
+	for _, manifest := range manifests {
+		if err := m.load(manifest); err != nil {
+			gologger.Warning().Msgf("Could not load plugin %s: %s\n", manifest.Name, err)
+		}
+	}
+	return nil
+}
+
+func (m *Manager) load(manifest Manifest) error {
+	if manifest.RPCVersion != RPCVersion {
+		return fmt.Errorf("plugin %s requires unsupported rpc version %d (nuclei supports %d)", manifest.Name, manifest.RPCVersion, RPCVersion)
+	}
+
+	binaryPath := filepath.Join(m.pluginsDir, manifest.Dir, manifest.Binary)
+	if m.verifier == nil {
+		return fmt.Errorf("refusing to load plugin %s: no signature verifier configured", manifest.Name)
+	}
+	data, err := os.ReadFile(binaryPath)
+	if err != nil {
+		return fmt.Errorf("could not read plugin binary: %w", err)
+	}
+	if err := m.verifier.Verify(data, manifest.Signature); err != nil {
+		return fmt.Errorf("plugin signature verification failed: %w", err)
+	}
+
+	cmd := exec.Command(binaryPath, "--rpc")
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	cmd.Stderr = os.Stderr
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+
+	client := jsonrpc.NewClient(&pluginConn{stdout, stdin})
+
+	m.mu.Lock()
+	m.plugins[manifest.Name] = &loadedPlugin{manifest: manifest, cmd: cmd, client: client}
+	m.mu.Unlock()
+
+	gologger.Info().Msgf("Loaded protocol plugin %s@%s\n", manifest.Name, manifest.Version)
+	return nil
+}
+
+// Health checks every loaded plugin and returns the set of plugin names that
+// failed to respond, so callers can surface them alongside DoHealthCheck.
+func (m *Manager) Health() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var unhealthy []string
+	for name, p := range m.plugins {
+		var pong string
+		if err := p.client.Call("Plugin.Ping", struct{}{}, &pong); err != nil {
+			unhealthy = append(unhealthy, name)
+		}
+	}
+	return unhealthy
+}
+
+// Get returns the RPC client for a loaded plugin by name, or false if no
+// such plugin was loaded. The `plugin:` request block in templates is routed
+// here by the loader.
+func (m *Manager) Get(name string) (*rpc.Client, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	p, ok := m.plugins[name]
+	if !ok {
+		return nil, false
+	}
+	return p.client, true
+}
+
+// Close terminates every loaded plugin process. It is called from
+// Runner.Close() so no plugin process outlives the scan.
+func (m *Manager) Close() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for name, p := range m.plugins {
+		_ = p.client.Close()
+		if err := p.cmd.Process.Kill(); err != nil {
+			gologger.Warning().Msgf("Could not terminate plugin %s: %s\n", name, err)
+		}
+		_ = p.cmd.Wait()
+	}
+	m.plugins = make(map[string]*loadedPlugin)
+}
@@ -0,0 +1,57 @@
+// Package plugins implements an out-of-process protocol plugin subsystem.
+// It lets nuclei load additional protocol executers (proprietary binary
+// handshakes, in-house APIs, ...) as external processes that speak a small
+// versioned RPC, without requiring the plugin's code to be vendored into the
+// nuclei binary itself.
+package plugins
+
+import (
+	"context"
+
+	"github.com/projectdiscovery/nuclei/v3/pkg/output"
+)
+
+// RPCVersion is the current plugin RPC protocol version. A plugin manifest
+// advertising an incompatible version is refused by the Manager.
+const RPCVersion = 1
+
+// ProtocolPlugin mirrors the protocols.Request contract that in-process
+// protocol executers implement, so a plugin-backed request behaves like any
+// other protocol from the engine's point of view.
+type ProtocolPlugin interface {
+	// Compile compiles the plugin request for the given input template data.
+	Compile(input []byte) error
+	// Requests returns the number of requests the plugin will perform for a
+	// single target.
+	Requests() int
+	// ExecuteWithResults executes the compiled request against target and
+	// streams results back through callback.
+	ExecuteWithResults(ctx context.Context, target string, callback func(*output.InternalWrappedEvent)) error
+	// Match runs the plugin's matchers against data, mirroring
+	// protocols.Request.Match.
+	Match(data map[string]interface{}, matcherName string) (bool, []string)
+	// Extract runs the plugin's extractors against data, mirroring
+	// protocols.Request.Extract.
+	Extract(data map[string]interface{}, extractorName string) map[string]struct{}
+}
+
+// Manifest describes a plugin discovered under ${NUCLEI_HOME}/plugins. Each
+// plugin directory must contain a plugin.yaml matching this schema.
+type Manifest struct {
+	Name         string   `yaml:"name"`
+	Version      string   `yaml:"version"`
+	RPCVersion   int      `yaml:"rpc-version"`
+	Capabilities []string `yaml:"capabilities"`
+	// Signature is a base64 signature of the plugin binary, verified by the
+	// Manager's SignatureVerifier (an ed25519 plugin-signing key, not a
+	// template-signing key) before the plugin is ever launched.
+	Signature string `yaml:"signature"`
+	// Binary is the path, relative to the manifest, of the executable to
+	// spawn for this plugin.
+	Binary string `yaml:"binary"`
+
+	// Dir is the plugin's directory name on disk, as returned by Discover.
+	// It is not part of the YAML schema - manifest.Name is author-controlled
+	// and must never be used to build a filesystem path.
+	Dir string `yaml:"-"`
+}
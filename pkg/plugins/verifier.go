@@ -0,0 +1,53 @@
+package plugins
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// Ed25519Verifier verifies a plugin binary's signature against a trusted
+// ed25519 public key: the manifest's Signature field is the base64 encoding
+// of the raw ed25519 signature over the binary's bytes. This key is separate
+// from the ECDSA keys used to sign templates.
+type Ed25519Verifier struct {
+	publicKey ed25519.PublicKey
+}
+
+// NewEd25519Verifier creates a verifier for the given public key.
+func NewEd25519Verifier(publicKey ed25519.PublicKey) *Ed25519Verifier {
+	return &Ed25519Verifier{publicKey: publicKey}
+}
+
+// Verify checks that signature, base64-encoded, is a valid ed25519
+// signature over data produced by the configured public key.
+func (v *Ed25519Verifier) Verify(data []byte, signature string) error {
+	sig, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return fmt.Errorf("could not decode plugin signature: %w", err)
+	}
+	if !ed25519.Verify(v.publicKey, data, sig) {
+		return errors.New("invalid plugin signature")
+	}
+	return nil
+}
+
+// VerifierFromKeyFile loads a PEM-encoded ed25519 public key from path and
+// returns a SignatureVerifier using it.
+func VerifierFromKeyFile(path string) (SignatureVerifier, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read plugin signing key: %w", err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("plugin signing key is not valid PEM")
+	}
+	if len(block.Bytes) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("plugin signing key has unexpected length %d, want %d", len(block.Bytes), ed25519.PublicKeySize)
+	}
+	return NewEd25519Verifier(ed25519.PublicKey(block.Bytes)), nil
+}
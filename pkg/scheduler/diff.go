@@ -0,0 +1,27 @@
+package scheduler
+
+import "github.com/projectdiscovery/nuclei/v3/pkg/output"
+
+// resultKey identifies a finding for diffing across runs of the same
+// schedule: same template, same matched host and same matcher name is
+// considered the same finding.
+func resultKey(event *output.ResultEvent) string {
+	return event.TemplateID + "|" + event.Host + "|" + event.MatcherName
+}
+
+// DiffNew returns the subset of current that were not present in previous,
+// so scheduled reruns can report only newly discovered findings downstream.
+func DiffNew(previous, current []*output.ResultEvent) []*output.ResultEvent {
+	seen := make(map[string]struct{}, len(previous))
+	for _, event := range previous {
+		seen[resultKey(event)] = struct{}{}
+	}
+
+	var fresh []*output.ResultEvent
+	for _, event := range current {
+		if _, ok := seen[resultKey(event)]; !ok {
+			fresh = append(fresh, event)
+		}
+	}
+	return fresh
+}
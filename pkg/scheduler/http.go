@@ -0,0 +1,65 @@
+package scheduler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// RegisterHandlers mounts the scheduler's control surface (/schedules,
+// /schedules/{id}/trigger, /runs) onto mux, typically the same mux the
+// pprof debug server already listens on.
+func (s *Scheduler) RegisterHandlers(mux *http.ServeMux) {
+	mux.HandleFunc("/schedules", s.handleSchedules)
+	mux.HandleFunc("/schedules/", s.handleScheduleAction)
+	mux.HandleFunc("/runs", s.handleRuns)
+}
+
+func (s *Scheduler) handleSchedules(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	writeJSON(w, s.List())
+}
+
+func (s *Scheduler) handleRuns(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	writeJSON(w, s.Runs())
+}
+
+// handleScheduleAction implements /schedules/{id}/trigger and
+// /schedules/{id}/pause.
+func (s *Scheduler) handleScheduleAction(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, "/schedules/")
+	parts := strings.SplitN(path, "/", 2)
+	if len(parts) != 2 {
+		http.NotFound(w, r)
+		return
+	}
+	id, action := parts[0], parts[1]
+
+	var err error
+	switch action {
+	case "trigger":
+		err = s.Trigger(id)
+	case "pause":
+		err = s.Pause(id)
+	default:
+		http.NotFound(w, r)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,267 @@
+// Package scheduler turns a one-shot nuclei scan into a long-lived,
+// cron-driven daemon: templates/targets are re-run on a schedule, with state
+// persisted to disk so a restart picks up where it left off.
+package scheduler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/projectdiscovery/gologger"
+	"github.com/robfig/cron/v3"
+)
+
+// CatchUpPolicy controls what happens to runs that were missed while the
+// scheduler process was not running.
+type CatchUpPolicy string
+
+const (
+	// CatchUpSkip drops any run that was missed.
+	CatchUpSkip CatchUpPolicy = "skip"
+	// CatchUpRunOnce runs a missed schedule exactly once, immediately, the
+	// next time the scheduler starts.
+	CatchUpRunOnce CatchUpPolicy = "run-once"
+)
+
+// ScheduleSpec describes a single recurring scan.
+type ScheduleSpec struct {
+	ID      string        `json:"id"`
+	Cron    string        `json:"cron"`
+	Paused  bool          `json:"paused"`
+	CatchUp CatchUpPolicy `json:"catch_up"`
+
+	// Templates/Workflows/Targets describe what to scan; TargetSource is one
+	// of "file", "list" or "uncover" describing how Targets is interpreted.
+	Templates    []string `json:"templates"`
+	Targets      []string `json:"targets"`
+	TargetSource string   `json:"target_source"`
+	// CruiseControlProfile selects a named cruisecontrol profile (standard,
+	// fast, etc.) for runs of this schedule.
+	CruiseControlProfile string `json:"cruise_control_profile"`
+
+	LastRun time.Time `json:"last_run"`
+	NextRun time.Time `json:"next_run"`
+}
+
+// RunFunc executes a single scan for spec and returns the scan ID assigned to
+// that run, which the Scheduler keeps in the run's RunRecord, or an error.
+type RunFunc func(ctx context.Context, spec ScheduleSpec) (scanID string, err error)
+
+// state is the on-disk persisted form of the scheduler, resume-config style.
+type state struct {
+	Specs []ScheduleSpec `json:"specs"`
+}
+
+// Scheduler runs a set of ScheduleSpecs on their cron schedules and persists
+// state to statePath so a restart resumes cleanly.
+type Scheduler struct {
+	statePath string
+	run       RunFunc
+
+	mu      sync.Mutex
+	specs   map[string]*ScheduleSpec
+	entries map[string]cron.EntryID
+	cronJob *cron.Cron
+
+	runs []RunRecord
+}
+
+// RunRecord is a single completed (or failed) scheduled run, kept in memory
+// for the /runs control endpoint.
+type RunRecord struct {
+	ScheduleID string    `json:"schedule_id"`
+	ScanID     string    `json:"scan_id"`
+	StartedAt  time.Time `json:"started_at"`
+	FinishedAt time.Time `json:"finished_at"`
+	Error      string    `json:"error,omitempty"`
+}
+
+// New creates a Scheduler. statePath is where schedule state (last/next run,
+// pause state) is persisted between restarts.
+func New(statePath string, run RunFunc) *Scheduler {
+	return &Scheduler{
+		statePath: statePath,
+		run:       run,
+		specs:     make(map[string]*ScheduleSpec),
+		entries:   make(map[string]cron.EntryID),
+		cronJob:   cron.New(),
+	}
+}
+
+// Load restores persisted state from statePath, if it exists. Start then
+// applies each spec's CatchUpPolicy for any run that was missed while the
+// process was down.
+func (s *Scheduler) Load() error {
+	data, err := os.ReadFile(s.statePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	var st state
+	if err := json.Unmarshal(data, &st); err != nil {
+		return fmt.Errorf("could not parse scheduler state: %w", err)
+	}
+	for i := range st.Specs {
+		spec := st.Specs[i]
+		s.specs[spec.ID] = &spec
+	}
+	return nil
+}
+
+// Save persists current schedule state to s.statePath.
+func (s *Scheduler) Save() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	st := state{}
+	for _, spec := range s.specs {
+		st.Specs = append(st.Specs, *spec)
+	}
+	data, err := json.MarshalIndent(st, "", "\t")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(s.statePath, data, 0644)
+}
+
+// AddSchedule registers spec and, unless paused, schedules it on the cron.
+func (s *Scheduler) AddSchedule(spec ScheduleSpec) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Keep the run times restored by Load, so Start can still apply the
+	// spec's CatchUpPolicy to a run missed while the process was down.
+	if existing, ok := s.specs[spec.ID]; ok {
+		if spec.LastRun.IsZero() {
+			spec.LastRun = existing.LastRun
+		}
+		if spec.NextRun.IsZero() {
+			spec.NextRun = existing.NextRun
+		}
+	}
+	s.specs[spec.ID] = &spec
+	if spec.Paused {
+		return nil
+	}
+	return s.scheduleLocked(&spec)
+}
+
+func (s *Scheduler) scheduleLocked(spec *ScheduleSpec) error {
+	entryID, err := s.cronJob.AddFunc(spec.Cron, func() {
+		s.trigger(spec.ID)
+	})
+	if err != nil {
+		return fmt.Errorf("invalid cron expression %q for schedule %s: %w", spec.Cron, spec.ID, err)
+	}
+	s.entries[spec.ID] = entryID
+	return nil
+}
+
+// Start runs missed catch-up jobs (per policy) and starts the cron loop.
+func (s *Scheduler) Start() {
+	s.mu.Lock()
+	for id, spec := range s.specs {
+		if spec.Paused {
+			continue
+		}
+		if _, scheduled := s.entries[id]; !scheduled {
+			_ = s.scheduleLocked(spec)
+		}
+		if !spec.NextRun.IsZero() && time.Now().After(spec.NextRun) && spec.CatchUp == CatchUpRunOnce {
+			go s.trigger(id)
+		}
+	}
+	s.mu.Unlock()
+	s.cronJob.Start()
+}
+
+// Stop stops the cron loop, letting in-flight runs finish.
+func (s *Scheduler) Stop() context.Context {
+	return s.cronJob.Stop()
+}
+
+// Trigger manually runs schedule id immediately, outside of its cron cadence.
+func (s *Scheduler) Trigger(id string) error {
+	s.mu.Lock()
+	_, ok := s.specs[id]
+	s.mu.Unlock()
+	if !ok {
+		return fmt.Errorf("unknown schedule: %s", id)
+	}
+	s.trigger(id)
+	return nil
+}
+
+// Pause stops a schedule from firing without removing its persisted state.
+func (s *Scheduler) Pause(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	spec, ok := s.specs[id]
+	if !ok {
+		return fmt.Errorf("unknown schedule: %s", id)
+	}
+	spec.Paused = true
+	if entryID, ok := s.entries[id]; ok {
+		s.cronJob.Remove(entryID)
+		delete(s.entries, id)
+	}
+	return nil
+}
+
+// List returns a snapshot of every registered schedule.
+func (s *Scheduler) List() []ScheduleSpec {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	specs := make([]ScheduleSpec, 0, len(s.specs))
+	for _, spec := range s.specs {
+		specs = append(specs, *spec)
+	}
+	return specs
+}
+
+// Runs returns a snapshot of completed run records.
+func (s *Scheduler) Runs() []RunRecord {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	runsCopy := make([]RunRecord, len(s.runs))
+	copy(runsCopy, s.runs)
+	return runsCopy
+}
+
+func (s *Scheduler) trigger(id string) {
+	s.mu.Lock()
+	spec, ok := s.specs[id]
+	if !ok {
+		s.mu.Unlock()
+		return
+	}
+	specCopy := *spec
+	s.mu.Unlock()
+
+	record := RunRecord{ScheduleID: id, StartedAt: time.Now()}
+	scanID, err := s.run(context.Background(), specCopy)
+	record.FinishedAt = time.Now()
+	record.ScanID = scanID
+	if err != nil {
+		record.Error = err.Error()
+		gologger.Error().Msgf("Scheduled run %s failed: %s\n", id, err)
+	}
+
+	s.mu.Lock()
+	spec.LastRun = record.FinishedAt
+	if entryID, ok := s.entries[id]; ok {
+		spec.NextRun = s.cronJob.Entry(entryID).Next
+	}
+	s.runs = append(s.runs, record)
+	s.mu.Unlock()
+
+	if err := s.Save(); err != nil {
+		gologger.Warning().Msgf("Could not persist scheduler state: %s\n", err)
+	}
+}
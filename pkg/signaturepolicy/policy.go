@@ -0,0 +1,132 @@
+// Package signaturepolicy implements per-source signature enforcement modes
+// for template loading, modeled on the mode-based design used by tools like
+// govulncheck (IMPORTS / BINARY / GOVULNCHECK).
+package signaturepolicy
+
+import (
+	"bufio"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// Mode controls how an unsigned or code-flagged template is handled for a
+// given template source.
+type Mode string
+
+const (
+	// Strict refuses unsigned and code-flagged templates outright; their
+	// presence aborts execution.
+	Strict Mode = "strict"
+	// Warn logs a warning and continues. This is nuclei's historical
+	// behavior and remains the default.
+	Warn Mode = "warn"
+	// AllowListed only runs an unsigned template if its SHA-256 digest
+	// appears in a user-supplied allow-list file; otherwise it is skipped.
+	AllowListed Mode = "allow-listed"
+)
+
+// SkippedUnsignedPolicyStats counts, per template source key, how many
+// unsigned templates were skipped because of the AllowListed policy. It
+// mirrors the shape of templates.SignatureStats so it can be displayed the
+// same way.
+var (
+	skippedMu             sync.Mutex
+	SkippedUnsignedPolicyStats = map[string]*atomic.Uint64{}
+)
+
+func counterFor(sourceKey string) *atomic.Uint64 {
+	skippedMu.Lock()
+	defer skippedMu.Unlock()
+	counter, ok := SkippedUnsignedPolicyStats[sourceKey]
+	if !ok {
+		counter = &atomic.Uint64{}
+		SkippedUnsignedPolicyStats[sourceKey] = counter
+	}
+	return counter
+}
+
+// Policy evaluates whether a template may be loaded, based on its source and
+// signature state.
+type Policy struct {
+	// Default is used for any source key without an explicit override.
+	Default Mode
+	// PerSource overrides Default for specific template source keys, so
+	// users can trust e.g. their own custom directory in warn mode while
+	// enforcing strict everywhere else.
+	PerSource map[string]Mode
+	// allowList holds SHA-256 digests permitted under AllowListed mode.
+	allowList map[string]struct{}
+}
+
+// New creates a Policy. allowListPath may be empty if no source uses
+// AllowListed mode.
+func New(defaultMode Mode, perSource map[string]Mode, allowListPath string) (*Policy, error) {
+	if defaultMode == "" {
+		defaultMode = Warn
+	}
+	policy := &Policy{Default: defaultMode, PerSource: perSource, allowList: map[string]struct{}{}}
+
+	if allowListPath == "" {
+		return policy, nil
+	}
+	file, err := os.Open(allowListPath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		digest := strings.TrimSpace(scanner.Text())
+		if digest == "" {
+			continue
+		}
+		policy.allowList[strings.ToLower(digest)] = struct{}{}
+	}
+	return policy, scanner.Err()
+}
+
+// ModeFor returns the effective mode for sourceKey, falling back to Default
+// when no per-source override is configured.
+func (p *Policy) ModeFor(sourceKey string) Mode {
+	if mode, ok := p.PerSource[sourceKey]; ok {
+		return mode
+	}
+	return p.Default
+}
+
+// Decision is the outcome of evaluating a template against the policy.
+type Decision int
+
+const (
+	// Allow means the template may be loaded and executed.
+	Allow Decision = iota
+	// Skip means the template is silently excluded from the run.
+	Skip
+	// Abort means the unsigned/code-flagged template must stop execution
+	// entirely (Strict mode).
+	Abort
+)
+
+// Evaluate decides what to do with a template from sourceKey given whether
+// it is signed/verified and its content digest.
+func (p *Policy) Evaluate(sourceKey string, signed bool, digest string) Decision {
+	if signed {
+		return Allow
+	}
+
+	switch p.ModeFor(sourceKey) {
+	case Strict:
+		return Abort
+	case AllowListed:
+		if _, ok := p.allowList[strings.ToLower(digest)]; ok {
+			return Allow
+		}
+		counterFor(sourceKey).Add(1)
+		return Skip
+	default:
+		return Allow
+	}
+}
@@ -0,0 +1,75 @@
+package signaturepolicy
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestEvaluateSignedAlwaysAllowed(t *testing.T) {
+	policy, err := New(Strict, nil, "")
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if decision := policy.Evaluate("any-source", true, "irrelevant"); decision != Allow {
+		t.Errorf("Evaluate(signed=true) = %v, want Allow", decision)
+	}
+}
+
+func TestEvaluateWarnModeAllowsUnsigned(t *testing.T) {
+	policy, err := New(Warn, nil, "")
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if decision := policy.Evaluate("custom", false, ""); decision != Allow {
+		t.Errorf("Evaluate(warn, unsigned) = %v, want Allow", decision)
+	}
+}
+
+func TestEvaluateStrictModeAborts(t *testing.T) {
+	policy, err := New(Strict, nil, "")
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if decision := policy.Evaluate("custom", false, ""); decision != Abort {
+		t.Errorf("Evaluate(strict, unsigned) = %v, want Abort", decision)
+	}
+}
+
+func TestEvaluateAllowListedMode(t *testing.T) {
+	dir := t.TempDir()
+	allowListPath := filepath.Join(dir, "allow.txt")
+	const allowedDigest = "abc123"
+	if err := os.WriteFile(allowListPath, []byte(allowedDigest+"\n"), 0644); err != nil {
+		t.Fatalf("could not write allow-list: %v", err)
+	}
+
+	policy, err := New(AllowListed, nil, allowListPath)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	if decision := policy.Evaluate("custom", false, allowedDigest); decision != Allow {
+		t.Errorf("Evaluate(allow-listed, matching digest) = %v, want Allow", decision)
+	}
+	if decision := policy.Evaluate("custom", false, "unknown-digest"); decision != Skip {
+		t.Errorf("Evaluate(allow-listed, unknown digest) = %v, want Skip", decision)
+	}
+	if got, want := SkippedUnsignedPolicyStats["custom"].Load(), uint64(1); got != want {
+		t.Errorf("SkippedUnsignedPolicyStats[custom] = %d, want %d", got, want)
+	}
+}
+
+func TestEvaluatePerSourceOverridesDefault(t *testing.T) {
+	policy, err := New(Strict, map[string]Mode{"trusted": Warn}, "")
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	if decision := policy.Evaluate("trusted", false, ""); decision != Allow {
+		t.Errorf("Evaluate(trusted source, unsigned) = %v, want Allow", decision)
+	}
+	if decision := policy.Evaluate("untrusted", false, ""); decision != Abort {
+		t.Errorf("Evaluate(untrusted source, unsigned) = %v, want Abort", decision)
+	}
+}
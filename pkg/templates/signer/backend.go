@@ -0,0 +1,312 @@
+package signer
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+	kmstypes "github.com/aws/aws-sdk-go-v2/service/kms/types"
+	gcpkms "cloud.google.com/go/kms/apiv1"
+	kmspb "cloud.google.com/go/kms/apiv1/kmspb"
+)
+
+// SignerBackend abstracts where a TemplateSigner's private key material
+// lives and how signing/verification is actually performed, so signing can
+// be backed by a local key, AWS KMS, Google Cloud KMS, or anything else
+// satisfying this interface - critical for CI systems that must not hold
+// signing material on disk.
+type SignerBackend interface {
+	// Sign returns the signature over digest along with the keyID that
+	// produced it, so the emitted signature line lets a verifier route to
+	// the right key.
+	Sign(digest []byte) (sig []byte, keyID string, err error)
+	// Verify checks that sig over digest was produced by keyID.
+	Verify(digest, sig []byte, keyID string) error
+	// PublicKey returns the public key backing this signer.
+	PublicKey() crypto.PublicKey
+	// GetKeyID returns the identifier Sign reports alongside its signature,
+	// without needing to actually sign anything.
+	GetKeyID() string
+}
+
+// LocalBackend signs with an in-memory ECDSA private key, matching nuclei's
+// historical local-key signing behavior.
+type LocalBackend struct {
+	KeyID      string
+	PrivateKey *ecdsa.PrivateKey
+}
+
+// NewLocalBackend wraps an existing ECDSA key as a SignerBackend.
+func NewLocalBackend(keyID string, privateKey *ecdsa.PrivateKey) *LocalBackend {
+	return &LocalBackend{KeyID: keyID, PrivateKey: privateKey}
+}
+
+func (l *LocalBackend) Sign(digest []byte) ([]byte, string, error) {
+	sig, err := ecdsa.SignASN1(rand.Reader, l.PrivateKey, digest)
+	if err != nil {
+		return nil, "", err
+	}
+	return sig, l.KeyID, nil
+}
+
+func (l *LocalBackend) Verify(digest, sig []byte, keyID string) error {
+	if keyID != "" && keyID != l.KeyID {
+		return fmt.Errorf("signature key id %q does not match local key id %q", keyID, l.KeyID)
+	}
+	if !ecdsa.VerifyASN1(&l.PrivateKey.PublicKey, digest, sig) {
+		return errors.New("signature verification failed")
+	}
+	return nil
+}
+
+func (l *LocalBackend) PublicKey() crypto.PublicKey {
+	return &l.PrivateKey.PublicKey
+}
+
+func (l *LocalBackend) GetKeyID() string {
+	return l.KeyID
+}
+
+// AWSKMSBackend signs/verifies template digests using an asymmetric AWS KMS
+// key, so the private key material never leaves KMS.
+type AWSKMSBackend struct {
+	client *kms.Client
+	keyID  string
+	pub    crypto.PublicKey
+}
+
+// NewAWSKMSBackend loads default AWS credentials/config and fetches the
+// public key for keyID once, so PublicKey() doesn't need a KMS round trip
+// on every call.
+func NewAWSKMSBackend(ctx context.Context, keyID string) (*AWSKMSBackend, error) {
+	cfg, err := awsconfig.LoadDefaultConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+	client := kms.NewFromConfig(cfg)
+
+	out, err := client.GetPublicKey(ctx, &kms.GetPublicKeyInput{KeyId: aws.String(keyID)})
+	if err != nil {
+		return nil, err
+	}
+	pub, err := x509.ParsePKIXPublicKey(out.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	return &AWSKMSBackend{client: client, keyID: keyID, pub: pub}, nil
+}
+
+func (a *AWSKMSBackend) Sign(digest []byte) ([]byte, string, error) {
+	out, err := a.client.Sign(context.Background(), &kms.SignInput{
+		KeyId:            aws.String(a.keyID),
+		Message:          digest,
+		MessageType:      kmstypes.MessageTypeDigest,
+		SigningAlgorithm: kmstypes.SigningAlgorithmSpecEcdsaSha256,
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	return out.Signature, a.keyID, nil
+}
+
+func (a *AWSKMSBackend) Verify(digest, sig []byte, keyID string) error {
+	out, err := a.client.Verify(context.Background(), &kms.VerifyInput{
+		KeyId:            aws.String(keyID),
+		Message:          digest,
+		MessageType:      kmstypes.MessageTypeDigest,
+		Signature:        sig,
+		SigningAlgorithm: kmstypes.SigningAlgorithmSpecEcdsaSha256,
+	})
+	if err != nil {
+		return err
+	}
+	if !out.SignatureValid {
+		return errors.New("aws kms signature verification failed")
+	}
+	return nil
+}
+
+func (a *AWSKMSBackend) PublicKey() crypto.PublicKey {
+	return a.pub
+}
+
+func (a *AWSKMSBackend) GetKeyID() string {
+	return a.keyID
+}
+
+// GCPKMSBackend signs/verifies template digests using a Google Cloud KMS
+// asymmetric signing key. keyName is the full crypto key version resource
+// name, e.g. "projects/p/locations/l/keyRings/r/cryptoKeys/k/cryptoKeyVersions/1".
+type GCPKMSBackend struct {
+	client  *gcpkms.KeyManagementClient
+	keyName string
+	pub     crypto.PublicKey
+}
+
+// NewGCPKMSBackend dials Cloud KMS using application-default credentials
+// and fetches the public key for keyName once.
+func NewGCPKMSBackend(ctx context.Context, keyName string) (*GCPKMSBackend, error) {
+	client, err := gcpkms.NewKeyManagementClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.GetPublicKey(ctx, &kmspb.GetPublicKeyRequest{Name: keyName})
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode([]byte(resp.Pem))
+	if block == nil {
+		return nil, errors.New("could not decode gcp kms public key pem")
+	}
+	pub, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	return &GCPKMSBackend{client: client, keyName: keyName, pub: pub}, nil
+}
+
+func (g *GCPKMSBackend) Sign(digest []byte) ([]byte, string, error) {
+	resp, err := g.client.AsymmetricSign(context.Background(), &kmspb.AsymmetricSignRequest{
+		Name:   g.keyName,
+		Digest: &kmspb.Digest{Digest: &kmspb.Digest_Sha256{Sha256: digest}},
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	return resp.Signature, g.keyName, nil
+}
+
+func (g *GCPKMSBackend) Verify(digest, sig []byte, keyID string) error {
+	ecdsaPub, ok := g.pub.(*ecdsa.PublicKey)
+	if !ok {
+		return errors.New("gcp kms public key is not ECDSA")
+	}
+	if !ecdsa.VerifyASN1(ecdsaPub, digest, sig) {
+		return errors.New("gcp kms signature verification failed")
+	}
+	return nil
+}
+
+func (g *GCPKMSBackend) PublicKey() crypto.PublicKey {
+	return g.pub
+}
+
+func (g *GCPKMSBackend) GetKeyID() string {
+	return g.keyName
+}
+
+// Signature block markers appended by SignWithBackend and read back by
+// VerifyWithBackend. Kept distinct from TemplateSigner's own local-key
+// signature line so the two signing paths never collide on the same
+// template.
+const (
+	backendKeyIDPrefix     = "# nuclei-signer-key-id: "
+	backendDigestPrefix    = "# nuclei-signer-digest-sha256: "
+	backendSignaturePrefix = "# nuclei-signer-signature: "
+)
+
+// SignWithBackend signs a template's content using backend - e.g. an
+// AWSKMSBackend or GCPKMSBackend, where the private key material never
+// leaves KMS - and appends a signature block that VerifyWithBackend can
+// check later. It is the SignerBackend equivalent of TemplateSigner.Sign,
+// used by NucleiEngine.SignTemplate when no local-key TemplateSigner was
+// given.
+func SignWithBackend(backend SignerBackend, data []byte) ([]byte, error) {
+	content, _, _, _, _ := stripBackendSignature(data)
+	digest := sha256.Sum256(content)
+	sig, keyID, err := backend.Sign(digest[:])
+	if err != nil {
+		return nil, fmt.Errorf("could not sign template: %w", err)
+	}
+
+	buff := bytes.NewBuffer(content)
+	buff.WriteString("\n" + backendKeyIDPrefix + keyID)
+	buff.WriteString("\n" + backendDigestPrefix + hex.EncodeToString(digest[:]))
+	buff.WriteString("\n" + backendSignaturePrefix + base64.StdEncoding.EncodeToString(sig))
+	return buff.Bytes(), nil
+}
+
+// VerifyWithBackend checks the signature block SignWithBackend appended to
+// data against backend. It returns an error if the block is missing, the
+// content no longer matches the digest it was signed against, or the
+// signature itself doesn't verify.
+func VerifyWithBackend(backend SignerBackend, data []byte) error {
+	content, keyID, digestHex, sigB64, ok := stripBackendSignature(data)
+	if !ok {
+		return errors.New("template has no backend signature block")
+	}
+	sig, err := base64.StdEncoding.DecodeString(sigB64)
+	if err != nil {
+		return fmt.Errorf("could not decode backend signature: %w", err)
+	}
+	digest := sha256.Sum256(content)
+	if hex.EncodeToString(digest[:]) != digestHex {
+		return errors.New("template content does not match its signed digest")
+	}
+	return backend.Verify(digest[:], sig, keyID)
+}
+
+// TemplateDigest returns the hex SHA-256 of a template's content, with any
+// previously appended SignWithBackend signature block stripped first, so it
+// matches the digest SignWithBackend actually signs.
+func TemplateDigest(data []byte) string {
+	content, _, _, _, _ := stripBackendSignature(data)
+	digest := sha256.Sum256(content)
+	return hex.EncodeToString(digest[:])
+}
+
+// stripBackendSignature removes a trailing SignWithBackend signature block
+// from data, returning the remaining content plus the block's fields. ok is
+// false if data has no such block.
+func stripBackendSignature(data []byte) (content []byte, keyID, digestHex, sigB64 string, ok bool) {
+	lines := strings.Split(string(data), "\n")
+
+	sigLine, digestLine, keyIDLine := -1, -1, -1
+	for i, line := range lines {
+		switch {
+		case strings.HasPrefix(line, backendSignaturePrefix):
+			sigLine = i
+		case strings.HasPrefix(line, backendDigestPrefix):
+			digestLine = i
+		case strings.HasPrefix(line, backendKeyIDPrefix):
+			keyIDLine = i
+		}
+	}
+	if sigLine == -1 || digestLine == -1 || keyIDLine == -1 {
+		return data, "", "", "", false
+	}
+
+	sigB64 = strings.TrimPrefix(lines[sigLine], backendSignaturePrefix)
+	digestHex = strings.TrimPrefix(lines[digestLine], backendDigestPrefix)
+	keyID = strings.TrimPrefix(lines[keyIDLine], backendKeyIDPrefix)
+
+	cut := sigLine
+	if digestLine < cut {
+		cut = digestLine
+	}
+	if keyIDLine < cut {
+		cut = keyIDLine
+	}
+	// lines[:cut] already reconstructs the original content byte-for-byte,
+	// trailing newline included: the "\n" SignWithBackend writes before the
+	// first marker line is the one strings.Split consumed at lines[cut], and
+	// when the content itself ended in a newline, lines[cut-1] is the empty
+	// string that restores it on Join. Trimming trailing newlines here would
+	// instead eat the original content's own trailing newline whenever it
+	// had one, breaking the digest round-trip.
+	content = []byte(strings.Join(lines[:cut], "\n"))
+	return content, keyID, digestHex, sigB64, true
+}
@@ -0,0 +1,76 @@
+package signer
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"strings"
+	"testing"
+)
+
+func newTestLocalBackend(t *testing.T, keyID string) *LocalBackend {
+	t.Helper()
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("could not generate test key: %v", err)
+	}
+	return NewLocalBackend(keyID, priv)
+}
+
+func TestSignWithBackendVerifyWithBackendRoundTrip(t *testing.T) {
+	backend := newTestLocalBackend(t, "test-key")
+	template := []byte("id: test-template\ninfo:\n  name: test\n")
+
+	signed, err := SignWithBackend(backend, template)
+	if err != nil {
+		t.Fatalf("SignWithBackend() error = %v", err)
+	}
+	if !strings.Contains(string(signed), backendKeyIDPrefix+"test-key") {
+		t.Errorf("signed template missing key id block")
+	}
+
+	if err := VerifyWithBackend(backend, signed); err != nil {
+		t.Errorf("VerifyWithBackend() error = %v, want nil", err)
+	}
+
+	if got := TemplateDigest(signed); got != TemplateDigest(template) {
+		t.Errorf("TemplateDigest(signed) = %s, want %s (digest of unsigned content)", got, TemplateDigest(template))
+	}
+}
+
+func TestVerifyWithBackendRejectsTamperedContent(t *testing.T) {
+	backend := newTestLocalBackend(t, "test-key")
+	template := []byte("id: test-template\ninfo:\n  name: test\n")
+
+	signed, err := SignWithBackend(backend, template)
+	if err != nil {
+		t.Fatalf("SignWithBackend() error = %v", err)
+	}
+
+	tampered := strings.Replace(string(signed), "test-template", "evil-template", 1)
+	if err := VerifyWithBackend(backend, []byte(tampered)); err == nil {
+		t.Error("VerifyWithBackend() on tampered content = nil error, want digest mismatch error")
+	}
+}
+
+func TestVerifyWithBackendRejectsMissingSignatureBlock(t *testing.T) {
+	backend := newTestLocalBackend(t, "test-key")
+	if err := VerifyWithBackend(backend, []byte("id: unsigned-template\n")); err == nil {
+		t.Error("VerifyWithBackend() on unsigned content = nil error, want missing signature block error")
+	}
+}
+
+func TestVerifyWithBackendRejectsWrongKey(t *testing.T) {
+	signingBackend := newTestLocalBackend(t, "signer-key")
+	verifyingBackend := newTestLocalBackend(t, "other-key")
+	template := []byte("id: test-template\n")
+
+	signed, err := SignWithBackend(signingBackend, template)
+	if err != nil {
+		t.Fatalf("SignWithBackend() error = %v", err)
+	}
+
+	if err := VerifyWithBackend(verifyingBackend, signed); err == nil {
+		t.Error("VerifyWithBackend() with mismatched key id = nil error, want error")
+	}
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/projectdiscovery/nuclei/v2/pkg/types"
+)
+
+// bindScanFlags registers the `nuclei scan` flag set on options. This is the
+// full flag set (not just the day-to-day ones) because bindCompatFlags
+// reuses it verbatim for the legacy top-level invocation, which must keep
+// supporting every flag existing scripts already rely on.
+func bindScanFlags(cmd *cobra.Command, options *types.Options) {
+	flags := cmd.Flags()
+
+	flags.StringVar(&options.ConfigFile, "config", "", "path to a YAML config file populating these same options")
+	flags.StringSliceVarP(&options.Targets, "target", "u", nil, "target URLs/hosts to scan")
+	flags.StringVarP(&options.TargetsFilePath, "list", "l", "", "path to file containing a list of target URLs/hosts to scan")
+	flags.StringSliceVarP(&options.Templates, "templates", "t", nil, "template or template directory paths to include in the scan")
+	flags.StringSliceVar(&options.Workflows, "workflows", nil, "workflow or workflow directory paths to include in the scan")
+	flags.StringSliceVar(&options.ExcludedTemplates, "exclude-templates", nil, "template or template directory paths to exclude from the scan")
+	flags.StringVar(&options.TemplatesDirectory, "templates-dir", "", "directory to use for storing nuclei-templates")
+	flags.BoolVar(&options.Validate, "validate", false, "validate the passed templates without running any scan")
+	flags.BoolVarP(&options.Verbose, "verbose", "v", false, "show verbose output")
+	flags.BoolVar(&options.VerboseVerbose, "vv", false, "display templates loaded for scan")
+	flags.BoolVar(&options.Debug, "debug", false, "show all requests and responses")
+	flags.BoolVar(&options.NoColor, "no-color", false, "disable output content coloring (ANSI escape codes)")
+	flags.BoolVarP(&options.Silent, "silent", "s", false, "display findings only")
+	flags.IntVar(&options.BulkSize, "bulk-size", 25, "maximum number of hosts to be analyzed in parallel per template")
+	flags.IntVarP(&options.TemplateThreads, "concurrency", "c", 10, "maximum number of templates to be executed in parallel")
+	flags.StringVar(&options.ResolversFile, "resolvers", "", "file containing resolver list for domain resolution")
+	flags.StringVar(&options.LogFormat, "log-format", "", "log output format (json for newline-delimited JSON records)")
+	flags.StringVar(&options.LogFile, "log-file", "", "file to tee logs to, independent of scan results output")
+
+	flags.StringVar(&options.Proxy, "proxy-list", "", "file containing list of proxies to rotate through")
+	flags.StringVarP(&options.ProxyURL, "proxy", "p", "", "http/socks5 proxy to use")
+	flags.StringVar(&options.ProxyStrategy, "proxy-strategy", "round-robin", "strategy to pick a proxy from the pool (round-robin, random, latency-weighted, fallback)")
+	flags.DurationVar(&options.ProxyHealthInterval, "proxy-health-interval", 30*time.Second, "interval between proxy pool health checks")
+	flags.StringVar(&options.ProxyProbeURL, "proxy-probe-url", "", "url used to validate end-to-end reachability through a proxy during health checks")
+
+	// Rate limiting and connection tuning.
+	flags.IntVar(&options.Retries, "retries", 1, "number of times to retry a failed request")
+	flags.IntVar(&options.MaxHostError, "max-host-error", 30, "max errors for a host before skipping its remaining requests")
+	flags.IntVar(&options.DialTimeout, "timeout", 10, "time to wait in seconds before a connection times out")
+
+	// Headless browser support.
+	flags.BoolVar(&options.Headless, "headless", false, "enable templates that require headless browser support")
+	flags.BoolVar(&options.OfflineHTTP, "passive", false, "enable passive mode matching templates against stored responses only")
+
+	// interactsh (OOB interaction) options.
+	flags.StringVar(&options.InteractshURL, "interactsh-server", "", "interactsh server url for self-hosted instances")
+	flags.StringVar(&options.InteractshToken, "interactsh-token", "", "authentication token for self-hosted interactsh server")
+	flags.BoolVar(&options.NoInteractsh, "no-interactsh", false, "disable interactsh server for OOB testing, exclude OOB based templates")
+	flags.IntVar(&options.InteractionsCacheSize, "interactions-cache-size", 5000, "number of requests to keep in the interactions cache")
+	flags.IntVar(&options.InteractionsPollDuration, "interactions-poll-duration", 5, "number of seconds to wait before each interaction poll")
+	flags.IntVar(&options.InteractionsCoolDownPeriod, "interactions-cooldown-period", 5, "extra time for interactions to be processed before the process exits")
+
+	// Severity / output filtering and reporting.
+	flags.StringSliceVar(&options.ExcludeTags, "exclude-tags", nil, "exclude templates with the provided tags")
+	flags.StringSliceVar(&options.ExcludeMatchers, "exclude-matchers", nil, "template matchers to exclude in result")
+	flags.BoolVar(&options.JSONL, "jsonl", false, "write output in JSONL(ines) format")
+	flags.BoolVar(&options.StatsJSON, "stats-json", false, "write statistics data to an output file in JSONL(ines) format")
+	flags.IntVar(&options.StatsInterval, "stats-interval", 5, "number of seconds to wait between showing a statistics update")
+	flags.StringVar(&options.ReportingDB, "reporting-db", "", "nuclei reporting database path")
+
+	// Template/engine update management.
+	flags.BoolVar(&options.UpdateTemplates, "update-templates", false, "update nuclei-templates to latest released version")
+	flags.BoolVar(&options.NewTemplates, "new-templates", false, "run only new templates added in the latest nuclei-templates release")
+	flags.StringSliceVar(&options.NewTemplatesWithVersion, "new-templates-version", nil, "run only new templates added in the given nuclei-templates version")
+	flags.StringVar(&options.UpdateChannel, "update-channel", "", "nuclei-templates release channel to update from")
+}
+
+// bindCompatFlags registers the same flags directly on the root command so
+// pre-existing invocations like `nuclei -u https://example.com -t cves/`
+// keep working without the `scan` subcommand for one release.
+func bindCompatFlags(cmd *cobra.Command) {
+	bindScanFlags(cmd, scanOptions)
+}
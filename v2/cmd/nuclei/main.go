@@ -0,0 +1,14 @@
+package main
+
+import (
+	"os"
+
+	"github.com/projectdiscovery/gologger"
+)
+
+func main() {
+	if err := rootCmd.Execute(); err != nil {
+		gologger.Fatal().Msgf("%s\n", err)
+		os.Exit(1)
+	}
+}
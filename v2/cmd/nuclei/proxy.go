@@ -0,0 +1,53 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/projectdiscovery/gologger"
+	"github.com/projectdiscovery/nuclei/v2/pkg/proxy"
+)
+
+var proxyCmd = &cobra.Command{
+	Use:   "proxy",
+	Short: "Inspect and test proxy configuration",
+}
+
+var proxyTestOptions = struct {
+	ProxyList string
+	ProbeURL  string
+}{}
+
+var proxyTestCmd = &cobra.Command{
+	Use:   "test",
+	Short: "Health-check a proxy list and report a healthy proxy the pool would use",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if proxyTestOptions.ProxyList == "" {
+			return fmt.Errorf("--list is required")
+		}
+		urls, err := readLines(proxyTestOptions.ProxyList)
+		if err != nil {
+			return err
+		}
+		pool, err := proxy.New(urls, proxy.Options{ProbeURL: proxyTestOptions.ProbeURL})
+		if err != nil {
+			return err
+		}
+		defer pool.Close()
+
+		chosen, err := pool.GetProxy()
+		if err != nil {
+			gologger.Error().Msgf("No healthy proxy found: %s\n", err)
+			return nil
+		}
+		gologger.Info().Msgf("Healthy proxy available, would use: %s\n", chosen)
+		return nil
+	},
+}
+
+func init() {
+	proxyTestCmd.Flags().StringVarP(&proxyTestOptions.ProxyList, "list", "l", "", "file containing list of proxies to test")
+	proxyTestCmd.Flags().StringVar(&proxyTestOptions.ProbeURL, "probe-url", "", "url used to validate end-to-end reachability through each proxy")
+	proxyCmd.AddCommand(proxyTestCmd)
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/projectdiscovery/nuclei/v2/internal/runner"
+)
+
+// rootCmd is the top-level nuclei command. Historically nuclei exposed a
+// single flat flag surface; that surface now lives under `nuclei scan` and
+// every subcommand owns its own flags and its own types.Options subset.
+//
+// For one release, running `nuclei` with the old top-level flags and no
+// subcommand still works: RunE falls back to the scan subcommand so existing
+// scripts and CI pipelines keep working while they migrate to `nuclei scan`.
+var rootCmd = &cobra.Command{
+	Use:   "nuclei",
+	Short: "Fast and customizable vulnerability scanner based on simple YAML based DSL",
+	// SilenceUsage avoids dumping the full help text on a scan failure -
+	// scan errors are already reported by the runner via gologger.
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runCompatScan(cmd, args)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(scanCmd)
+	rootCmd.AddCommand(templatesCmd)
+	rootCmd.AddCommand(versionCmd)
+	rootCmd.AddCommand(proxyCmd)
+
+	// legacy flags are bound directly on the root command so `nuclei -u ...`
+	// keeps working without requiring `nuclei scan -u ...`
+	bindCompatFlags(rootCmd)
+}
+
+// runCompatScan maps the deprecated top-level invocation onto `scan`.
+func runCompatScan(cmd *cobra.Command, args []string) error {
+	options := compatOptionsToScanOptions()
+	return runner.ParseOptionsAndExecute(options)
+}
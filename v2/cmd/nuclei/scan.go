@@ -0,0 +1,31 @@
+package main
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/projectdiscovery/nuclei/v2/internal/runner"
+	"github.com/projectdiscovery/nuclei/v2/pkg/types"
+)
+
+// scanOptions holds the flag values for `nuclei scan`, the moral equivalent
+// of the old flat top-level flag set.
+var scanOptions = &types.Options{}
+
+var scanCmd = &cobra.Command{
+	Use:   "scan",
+	Short: "Run a vulnerability scan against the given targets",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runner.ParseOptionsAndExecute(scanOptions)
+	},
+}
+
+func init() {
+	bindScanFlags(scanCmd, scanOptions)
+}
+
+// compatOptionsToScanOptions maps the legacy root-level flags (bound by
+// bindCompatFlags in flags.go) onto scanOptions for the one-release
+// compatibility shim.
+func compatOptionsToScanOptions() *types.Options {
+	return scanOptions
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/projectdiscovery/gologger"
+	"github.com/projectdiscovery/nuclei/v2/pkg/catalog/config"
+	"github.com/projectdiscovery/nuclei/v2/pkg/installer"
+)
+
+var templatesCmd = &cobra.Command{
+	Use:   "templates",
+	Short: "Manage the local nuclei-templates installation",
+}
+
+var templatesUpdateCmd = &cobra.Command{
+	Use:   "update",
+	Short: "Update nuclei-templates to the latest release",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		tm := &installer.TemplateManager{}
+		if err := tm.FreshInstallIfNotExists(); err != nil {
+			return err
+		}
+		if err := tm.UpdateIfOutdated(); err != nil {
+			return err
+		}
+		gologger.Info().Msgf("nuclei-templates are up to date: %s", config.DefaultConfig.TemplateVersion)
+		return nil
+	},
+}
+
+var templatesValidateOptions = struct {
+	Templates []string
+	Workflows []string
+}{}
+
+var templatesValidateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Validate templates without running a scan",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		validateOptions := scanOptions
+		validateOptions.Templates = templatesValidateOptions.Templates
+		validateOptions.Workflows = templatesValidateOptions.Workflows
+		validateOptions.Validate = true
+		return runCompatScan(cmd, args)
+	},
+}
+
+func init() {
+	templatesValidateCmd.Flags().StringSliceVarP(&templatesValidateOptions.Templates, "templates", "t", nil, "template or template directory paths to validate")
+	templatesValidateCmd.Flags().StringSliceVar(&templatesValidateOptions.Workflows, "workflows", nil, "workflow or workflow directory paths to validate")
+
+	templatesCmd.AddCommand(templatesUpdateCmd)
+	templatesCmd.AddCommand(templatesValidateCmd)
+}
@@ -0,0 +1,22 @@
+package main
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/projectdiscovery/gologger"
+	"github.com/projectdiscovery/nuclei/v2/pkg/catalog/config"
+)
+
+var versionCmd = &cobra.Command{
+	Use:   "version",
+	Short: "Print the nuclei engine and templates version",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		gologger.Info().Msgf("Current Version: %s\n", config.Version)
+		configuration, err := config.ReadConfiguration()
+		if err != nil {
+			return err
+		}
+		gologger.Info().Msgf("Current nuclei-templates version: %s (%s)\n", configuration.TemplateVersion, configuration.TemplatesDirectory)
+		return nil
+	},
+}
@@ -0,0 +1,169 @@
+package runner
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/projectdiscovery/gologger"
+	"github.com/projectdiscovery/nuclei/v2/pkg/types"
+)
+
+// ProxyConfig describes a single proxy entry in the YAML config file.
+type ProxyConfig struct {
+	URL    string `yaml:"url"`
+	Type   string `yaml:"type"`
+	Auth   string `yaml:"auth"`
+	Weight int    `yaml:"weight"`
+}
+
+// proxyURL builds the fully-qualified proxy URL that the rest of the runner
+// works with (the same form accepted by -proxy on the CLI), folding in Type
+// and Auth when url itself doesn't already carry a scheme/userinfo. Weight
+// is reserved for a future weighted pool strategy and is not consulted yet.
+func (p ProxyConfig) proxyURL() string {
+	raw := p.URL
+	if p.Type != "" && !strings.Contains(raw, "://") {
+		raw = p.Type + "://" + raw
+	}
+	if p.Auth != "" && !strings.Contains(raw, "@") {
+		if idx := strings.Index(raw, "://"); idx != -1 {
+			raw = raw[:idx+3] + p.Auth + "@" + raw[idx+3:]
+		}
+	}
+	return raw
+}
+
+// HeadlessConfig mirrors the headless-browser tuning flags.
+type HeadlessConfig struct {
+	Enabled       bool   `yaml:"enabled"`
+	ShowBrowser   bool   `yaml:"show-browser"`
+	UseChrome     string `yaml:"use-installed-chrome"`
+	HeadlessBulk  int    `yaml:"bulk-size"`
+	PageThreads   int    `yaml:"threads"`
+}
+
+// TemplatesConfig mirrors the template-loading related flags.
+type TemplatesConfig struct {
+	Directory string   `yaml:"directory"`
+	Paths     []string `yaml:"paths"`
+	Workflows []string `yaml:"workflows"`
+	Excluded  []string `yaml:"excluded"`
+}
+
+// FileConfig is the top-level schema accepted by `-config nuclei.yaml`. Every
+// section is optional; CLI flags always take precedence over values loaded
+// from this file.
+type FileConfig struct {
+	Targets     []string        `yaml:"targets"`
+	TargetsFile string          `yaml:"targets-file"`
+	Resolvers   []string        `yaml:"resolvers"`
+	Proxies     []ProxyConfig   `yaml:"proxies"`
+	RateLimits  RateLimitConfig `yaml:"rate-limits"`
+	Headless    HeadlessConfig  `yaml:"headless"`
+	Templates   TemplatesConfig `yaml:"templates"`
+}
+
+// RateLimitConfig mirrors the rate-limiting flags.
+type RateLimitConfig struct {
+	RateLimit         int `yaml:"requests-per-second"`
+	RateLimitDuration int `yaml:"duration-seconds"`
+	BulkSize          int `yaml:"bulk-size"`
+	Concurrency       int `yaml:"concurrency"`
+}
+
+// LoadConfigFile reads path, validates it against the expected schema and
+// applies every set field onto options that the CLI flags left at their
+// zero value, so a value explicitly passed on the command line always wins
+// over the same value coming from the config file.
+func LoadConfigFile(path string, options *types.Options) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("could not read config file: %w", err)
+	}
+
+	var cfg FileConfig
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&cfg); err != nil {
+		return formatConfigError(path, err)
+	}
+
+	applyFileConfig(&cfg, options)
+	return nil
+}
+
+// formatConfigError rewrites yaml.v3's *yaml.TypeError into a single
+// readable message that includes the file name so unknown/misspelled keys
+// point the user straight at the offending line.
+func formatConfigError(path string, err error) error {
+	if typeErr, ok := err.(*yaml.TypeError); ok {
+		msg := fmt.Sprintf("invalid config file %s:", path)
+		for _, e := range typeErr.Errors {
+			msg += "\n  " + e
+		}
+		return fmt.Errorf("%s", msg)
+	}
+	return fmt.Errorf("invalid config file %s: %w", path, err)
+}
+
+func applyFileConfig(cfg *FileConfig, options *types.Options) {
+	if len(cfg.Targets) > 0 && len(options.Targets) == 0 {
+		options.Targets = cfg.Targets
+	}
+	if cfg.TargetsFile != "" && options.TargetsFilePath == "" {
+		options.TargetsFilePath = cfg.TargetsFile
+	}
+	if len(cfg.Resolvers) > 0 && options.ResolversFile == "" {
+		options.InternalResolversList = cfg.Resolvers
+	}
+	if len(cfg.Proxies) > 0 && options.Proxy == "" && len(options.ProxyURLList) == 0 {
+		for _, p := range cfg.Proxies {
+			options.ProxyURLList = append(options.ProxyURLList, p.proxyURL())
+		}
+	}
+	if cfg.Templates.Directory != "" && options.TemplatesDirectory == "" {
+		options.TemplatesDirectory = cfg.Templates.Directory
+	}
+	if len(cfg.Templates.Paths) > 0 && len(options.Templates) == 0 {
+		options.Templates = cfg.Templates.Paths
+	}
+	if len(cfg.Templates.Workflows) > 0 && len(options.Workflows) == 0 {
+		options.Workflows = cfg.Templates.Workflows
+	}
+	if len(cfg.Templates.Excluded) > 0 && len(options.ExcludedTemplates) == 0 {
+		options.ExcludedTemplates = cfg.Templates.Excluded
+	}
+	if cfg.Headless.Enabled && !options.Headless {
+		options.Headless = true
+	}
+	if cfg.Headless.ShowBrowser && !options.ShowBrowser {
+		options.ShowBrowser = true
+	}
+	if cfg.Headless.UseChrome != "" && options.SystemChromePath == "" {
+		options.SystemChromePath = cfg.Headless.UseChrome
+	}
+	if cfg.Headless.HeadlessBulk > 0 && options.HeadlessBulkSize == 0 {
+		options.HeadlessBulkSize = cfg.Headless.HeadlessBulk
+	}
+	if cfg.Headless.PageThreads > 0 && options.PageThreads == 0 {
+		options.PageThreads = cfg.Headless.PageThreads
+	}
+	if cfg.RateLimits.RateLimit > 0 && options.RateLimit == 0 {
+		options.RateLimit = cfg.RateLimits.RateLimit
+	}
+	if cfg.RateLimits.RateLimitDuration > 0 && options.RateLimitDuration == 0 {
+		options.RateLimitDuration = cfg.RateLimits.RateLimitDuration
+	}
+	if cfg.RateLimits.BulkSize > 0 && options.BulkSize == 0 {
+		options.BulkSize = cfg.RateLimits.BulkSize
+	}
+	if cfg.RateLimits.Concurrency > 0 && options.TemplateThreads == 0 {
+		options.TemplateThreads = cfg.RateLimits.Concurrency
+	}
+
+	gologger.Verbose().Msgf("Applied configuration from config file\n")
+}
@@ -0,0 +1,25 @@
+package runner
+
+import (
+	"github.com/projectdiscovery/nuclei/v2/pkg/types"
+)
+
+// ParseOptionsAndExecute parses the given options and runs a single scan to
+// completion. It is the shared entry point used by both the `nuclei scan`
+// subcommand and the legacy top-level compatibility shim, so the two keep
+// identical behavior.
+func ParseOptionsAndExecute(options *types.Options) error {
+	ParseOptions(options)
+
+	nucleiRunner, err := New(options)
+	if err != nil {
+		return err
+	}
+	if nucleiRunner == nil {
+		return nil
+	}
+	defer nucleiRunner.Close()
+	defer closeLogFile()
+
+	return nucleiRunner.RunEnumeration()
+}
@@ -0,0 +1,106 @@
+package runner
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/projectdiscovery/gologger"
+	"github.com/projectdiscovery/gologger/formatter"
+)
+
+// jsonLogRecord is a single newline-delimited JSON log line emitted when
+// options.LogFormat is "json".
+type jsonLogRecord struct {
+	Timestamp  time.Time              `json:"timestamp"`
+	Level      string                 `json:"level"`
+	Message    string                 `json:"message"`
+	TemplateID string                 `json:"template_id,omitempty"`
+	Target     string                 `json:"target,omitempty"`
+	Fields     map[string]interface{} `json:"fields,omitempty"`
+}
+
+var (
+	logFileMutex sync.Mutex
+	logFile      io.WriteCloser
+	useJSONLogs  bool
+)
+
+// configureLogFormat switches gologger output to newline-delimited JSON when
+// requested and, when logFilePath is set, opens it as a log file independent
+// of the scan results writer. Only lines logged through WithFields are written
+// to that file; other gologger output is not. It is called from
+// configureOutput.
+func configureLogFormat(logFormat, logFilePath string) {
+	useJSONLogs = logFormat == "json"
+	if useJSONLogs {
+		// Reconfigure the default logger itself, not just WithFields: every
+		// existing gologger.Info()/Warning()/Error() call site across the
+		// codebase goes through DefaultLogger, so -log-format json would
+		// otherwise only affect the handful of callers that use WithFields.
+		gologger.DefaultLogger.SetFormatter(formatter.NewJSON())
+	}
+
+	if logFilePath == "" {
+		return
+	}
+	file, err := os.OpenFile(logFilePath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		gologger.Error().Msgf("Could not open log file %s: %s\n", logFilePath, err)
+		return
+	}
+	logFileMutex.Lock()
+	logFile = file
+	logFileMutex.Unlock()
+}
+
+// closeLogFile flushes and closes the log file tee, if one was configured.
+func closeLogFile() {
+	logFileMutex.Lock()
+	defer logFileMutex.Unlock()
+	if logFile != nil {
+		_ = logFile.Close()
+		logFile = nil
+	}
+}
+
+// WithFields logs message at info level, attaching templateID/target/fields
+// as structured context. In "json" log format this becomes a single
+// newline-delimited JSON record; otherwise it falls back to the familiar
+// human-readable gologger line.
+func WithFields(message, templateID, target string, fields map[string]interface{}) {
+	if !useJSONLogs {
+		gologger.Info().Msgf("%s\n", message)
+		writeToLogFile(message)
+		return
+	}
+
+	record := jsonLogRecord{
+		Timestamp:  time.Now(),
+		Level:      "info",
+		Message:    message,
+		TemplateID: templateID,
+		Target:     target,
+		Fields:     fields,
+	}
+	data, err := json.Marshal(record)
+	if err != nil {
+		gologger.Error().Msgf("Could not marshal structured log record: %s\n", err)
+		return
+	}
+	// Always emit the record to the console: -log-file is an independent
+	// tee, not a replacement for the console output -log-format promises.
+	_, _ = os.Stderr.Write(append(data, '\n'))
+	writeToLogFile(string(data))
+}
+
+func writeToLogFile(line string) {
+	logFileMutex.Lock()
+	defer logFileMutex.Unlock()
+	if logFile == nil {
+		return
+	}
+	_, _ = logFile.Write([]byte(line + "\n"))
+}
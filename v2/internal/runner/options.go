@@ -3,13 +3,10 @@ package runner
 import (
 	"bufio"
 	"errors"
-	"fmt"
-	"net"
 	"net/url"
 	"os"
 	"path/filepath"
 	"strings"
-	"time"
 
 	"github.com/projectdiscovery/fileutil"
 	"github.com/projectdiscovery/gologger"
@@ -17,9 +14,16 @@ import (
 	"github.com/projectdiscovery/gologger/levels"
 	"github.com/projectdiscovery/nuclei/v2/pkg/catalog/config"
 	"github.com/projectdiscovery/nuclei/v2/pkg/protocols/common/protocolinit"
+	"github.com/projectdiscovery/nuclei/v2/pkg/proxy"
 	"github.com/projectdiscovery/nuclei/v2/pkg/types"
 )
 
+// ProxyPool is the global pool of health-checked proxies built from
+// options.ProxyURLList. It is nil when a single static proxy was configured
+// or no proxies were provided. Protocol dialers consult it via GetProxy to
+// spread load and transparently survive individual proxy failures.
+var ProxyPool *proxy.Pool
+
 // ParseOptions parses the command line flags provided by a user
 func ParseOptions(options *types.Options) {
 	// Check if stdin pipe was given
@@ -30,6 +34,15 @@ func ParseOptions(options *types.Options) {
 		options.Verbose = true
 	}
 
+	// Load the YAML config file first, if any. The CLI flags are already
+	// parsed at this point; LoadConfigFile only fills options they left at
+	// their zero value, so flags still override values coming from it.
+	if options.ConfigFile != "" {
+		if err := LoadConfigFile(options.ConfigFile, options); err != nil {
+			gologger.Fatal().Msgf("%s\n", err)
+		}
+	}
+
 	// Read the inputs and configure the logging
 	configureOutput(options)
 	// Show the user the banner
@@ -143,6 +156,11 @@ func configureOutput(options *types.Options) {
 	if options.Silent {
 		gologger.DefaultLogger.SetMaxLevel(levels.LevelSilent)
 	}
+
+	// -log-format json switches to machine-consumable newline-delimited JSON
+	// logs; -log-file copies WithFields log lines to a file independently of
+	// scan results.
+	configureLogFormat(options.LogFormat, options.LogFile)
 }
 
 // loadResolvers loads resolvers from both user provided flag and file
@@ -171,7 +189,10 @@ func loadResolvers(options *types.Options) {
 	}
 }
 
-// loadProxies load list of proxy servers from file
+// loadProxies load list of proxy servers from file and, when more than one
+// proxy is available, builds a health-checked ProxyPool. The non-HTTP protocol
+// dialer rotates through the pool on every dial; HTTP templates use the single
+// healthy proxy picked from it here for the whole run.
 func loadProxies(options *types.Options) {
 	if options.Proxy == "" {
 		return
@@ -183,44 +204,57 @@ func loadProxies(options *types.Options) {
 	defer file.Close()
 	scanner := bufio.NewScanner(file)
 	for scanner.Scan() {
-		proxy := scanner.Text()
-		if proxy == "" {
+		entry := scanner.Text()
+		if entry == "" {
 			continue
 		}
-		if err := validateProxy(proxy); err != nil {
+		if err := validateProxy(entry); err != nil {
 			gologger.Fatal().Msgf("%s\n", err)
 		}
-		options.ProxyURLList = append(options.ProxyURLList, proxy)
+		options.ProxyURLList = append(options.ProxyURLList, entry)
 	}
 	if len(options.ProxyURLList) == 0 {
 		gologger.Fatal().Msgf("Could not find any proxy in the file\n")
-	} else {
-		done := make(chan bool)
-		for _, ip := range options.ProxyURLList {
-			go runProxyConnectivity(ip, options, done)
-		}
-		<-done
-		close(done)
+		return
 	}
-}
-func runProxyConnectivity(ip string, options *types.Options, done chan bool) {
-	if proxy, err := testProxyConnection(ip); err == nil {
-		if options.ProxyURL == "" && options.ProxySocksURL == "" {
-			if valid := assignProxy(proxy, options); valid {
-				done <- true
-			}
+
+	if len(options.ProxyURLList) == 1 {
+		if valid := assignProxy(options.ProxyURLList[0], options); !valid {
+			gologger.Fatal().Msgf("Invalid proxy format: %s\n", options.ProxyURLList[0])
 		}
+		return
 	}
-}
-func testProxyConnection(proxy string) (string, error) {
-	ip, _ := url.Parse(proxy)
-	timeout := time.Duration(1 * time.Second)
-	_, err := net.DialTimeout("tcp", fmt.Sprintf("%s:%s", ip.Hostname(), ip.Port()), timeout)
+
+	pool, err := proxy.New(options.ProxyURLList, proxy.Options{
+		Strategy:       proxy.Strategy(options.ProxyStrategy),
+		HealthInterval: options.ProxyHealthInterval,
+		ProbeURL:       options.ProxyProbeURL,
+	})
 	if err != nil {
-		return "", err
+		gologger.Fatal().Msgf("Could not create proxy pool: %s\n", err)
+	}
+	ProxyPool = pool
+	// Register the pool with protocolinit too, so the shared dialer it
+	// builds for non-HTTP (network/ssl/websocket) template executors
+	// rotates across proxies and skips unhealthy ones on every dial.
+	protocolinit.SetProxySource(pool)
+
+	// The HTTP protocol path, unlike network/ssl/websocket, reads a single
+	// static proxy from options.ProxyURL/ProxySocksURL rather than
+	// consulting protocolinit's pooled dialer - so without this, HTTP
+	// templates (the dominant case) would dial targets directly with no
+	// proxy at all whenever more than one -proxy entry was given. Seed it
+	// with a healthy pick from the pool, restoring the pre-pool "use the
+	// first working proxy" behavior for HTTP.
+	proxyURL, err := pool.GetProxy()
+	if err != nil {
+		gologger.Fatal().Msgf("Could not get a healthy proxy from the pool: %s\n", err)
+	}
+	if valid := assignProxy(proxyURL, options); !valid {
+		gologger.Fatal().Msgf("Invalid proxy format: %s\n", proxyURL)
 	}
-	return proxy, nil
 }
+
 func assignProxy(proxy string, options *types.Options) bool {
 	var validConfig bool = true
 	if strings.HasPrefix(proxy, "http") || strings.HasPrefix(proxy, "https") {
@@ -0,0 +1,37 @@
+// Package dataformat implements structural encoders/decoders for the wire
+// formats nuclei's fuzzing engine can mutate request bodies as, so fuzzed
+// values stay valid for the format instead of only ever being treated as
+// opaque text.
+package dataformat
+
+// Decoder is a single wire format a request body can be decoded from and
+// re-encoded as after fuzzing its values.
+type Decoder interface {
+	// IsType reports whether data looks like this format.
+	IsType(data string) bool
+	// Encode serializes data back into this format.
+	Encode(data map[string]interface{}) ([]byte, error)
+	// Decode parses data from this format into a generic key/value map.
+	Decode(data []byte) (map[string]interface{}, error)
+	// Name returns the format's identifier, e.g. "json", "xml", "yaml".
+	Name() string
+}
+
+// decoders holds every registered Decoder, tried in order by Detect. XML is
+// checked before YAML since YAML's IsType already declines braces/brackets,
+// but ordering still matters for formats added later that may overlap.
+var decoders = []Decoder{
+	NewXML(),
+	NewYAML(),
+}
+
+// Detect returns the first registered Decoder whose IsType matches data, or
+// nil if no format recognizes it.
+func Detect(data string) Decoder {
+	for _, decoder := range decoders {
+		if decoder.IsType(data) {
+			return decoder
+		}
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package dataformat
+
+import "testing"
+
+func TestDetectXML(t *testing.T) {
+	decoder := Detect("<root><a>1</a></root>")
+	if decoder == nil {
+		t.Fatal("Detect() = nil, want XML decoder")
+	}
+	if got := decoder.Name(); got != "xml" {
+		t.Errorf("Detect() name = %q, want %q", got, "xml")
+	}
+}
+
+func TestDetectYAML(t *testing.T) {
+	decoder := Detect("foo: bar\nbaz: 1\n")
+	if decoder == nil {
+		t.Fatal("Detect() = nil, want YAML decoder")
+	}
+	if got := decoder.Name(); got != "yaml" {
+		t.Errorf("Detect() name = %q, want %q", got, "yaml")
+	}
+}
+
+func TestYAMLRoundTripPreservesOrderAndStyle(t *testing.T) {
+	input := "zebra: \"quoted\"\napple: 1\nmango: plain\n"
+	y := NewYAML()
+	data, err := y.Decode([]byte(input))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	data["apple"] = 2
+
+	out, err := y.Encode(data)
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	want := "zebra: \"quoted\"\napple: 2\nmango: plain\n"
+	if got := string(out); got != want {
+		t.Errorf("Encode() = %q, want %q (key order and untouched scalar styles preserved)", got, want)
+	}
+}
+
+func TestDetectNoneForJSONAndPlainText(t *testing.T) {
+	tests := []string{
+		`{"foo": "bar"}`,
+		`["foo", "bar"]`,
+		"just some plain text without a colon",
+		"",
+	}
+	for _, data := range tests {
+		if decoder := Detect(data); decoder != nil {
+			t.Errorf("Detect(%q) = %s decoder, want nil", data, decoder.Name())
+		}
+	}
+}
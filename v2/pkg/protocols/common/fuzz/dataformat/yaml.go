@@ -0,0 +1,130 @@
+package dataformat
+
+import (
+	"reflect"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// YAML is a YAML encoder
+type YAML struct{}
+
+// NewYAML returns a new YAML encoder
+func NewYAML() *YAML {
+	return &YAML{}
+}
+
+// IsType returns true if the data is YAML encoded
+func (y *YAML) IsType(data string) bool {
+	trimmed := strings.TrimSpace(data)
+	if trimmed == "" {
+		return false
+	}
+	// JSON is technically valid YAML, so don't claim braces/brackets - those
+	// are handled by the JSON encoder.
+	if strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "[") {
+		return false
+	}
+	if strings.HasPrefix(trimmed, "---") {
+		return true
+	}
+
+	var node yaml.Node
+	if err := yaml.Unmarshal([]byte(data), &node); err != nil {
+		return false
+	}
+	if len(node.Content) == 0 || node.Content[0].Kind != yaml.MappingNode {
+		return false
+	}
+	// require at least one top level "key: value" looking line, so plain
+	// text (no colon) isn't misidentified as YAML.
+	return strings.Contains(trimmed, ":")
+}
+
+// yamlNodeKey is the map key Decode stashes the parsed mapping *yaml.Node
+// under, so Encode can patch only the values the fuzzer actually changed
+// back into that same node instead of re-marshaling the map from scratch -
+// preserving key order and every untouched scalar's original style (quoted
+// vs plain, block vs flow). No real YAML document can decode to a key
+// containing a NUL byte, so this can't collide with a decoded field.
+const yamlNodeKey = "\x00yaml_node"
+
+// Encode encodes the data into YAML format
+func (y *YAML) Encode(data map[string]interface{}) ([]byte, error) {
+	mapping, _ := data[yamlNodeKey].(*yaml.Node)
+	if mapping == nil || mapping.Kind != yaml.MappingNode {
+		return yaml.Marshal(withoutYAMLNodeKey(data))
+	}
+
+	seen := make(map[string]bool, len(mapping.Content)/2)
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		key := mapping.Content[i].Value
+		seen[key] = true
+		value, ok := data[key]
+		if !ok {
+			continue
+		}
+		var original interface{}
+		if err := mapping.Content[i+1].Decode(&original); err == nil && reflect.DeepEqual(original, value) {
+			// untouched by the fuzzer - leave the node as-is so its style survives
+			continue
+		}
+		if err := mapping.Content[i+1].Encode(value); err != nil {
+			return nil, err
+		}
+	}
+	for key, value := range data {
+		if key == yamlNodeKey || seen[key] {
+			continue
+		}
+		var keyNode, valueNode yaml.Node
+		if err := keyNode.Encode(key); err != nil {
+			return nil, err
+		}
+		if err := valueNode.Encode(value); err != nil {
+			return nil, err
+		}
+		mapping.Content = append(mapping.Content, &keyNode, &valueNode)
+	}
+	return yaml.Marshal(mapping)
+}
+
+// Decode decodes the data from YAML format
+func (y *YAML) Decode(data []byte) (map[string]interface{}, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return map[string]interface{}{}, nil
+	}
+	mapping := doc.Content[0]
+
+	result := make(map[string]interface{}, len(mapping.Content)/2+1)
+	if err := mapping.Decode(&result); err != nil {
+		return nil, err
+	}
+	result[yamlNodeKey] = mapping
+	return result, nil
+}
+
+// withoutYAMLNodeKey returns data with yamlNodeKey removed, for the
+// fallback path where Encode has no original node to patch.
+func withoutYAMLNodeKey(data map[string]interface{}) map[string]interface{} {
+	if _, ok := data[yamlNodeKey]; !ok {
+		return data
+	}
+	clean := make(map[string]interface{}, len(data)-1)
+	for k, v := range data {
+		if k != yamlNodeKey {
+			clean[k] = v
+		}
+	}
+	return clean
+}
+
+// Name returns the name of the encoder
+func (y *YAML) Name() string {
+	return "yaml"
+}
@@ -0,0 +1,66 @@
+// Package protocolinit performs one-time setup that protocol executors rely
+// on before any template runs, and exposes the shared state they consult
+// while running (currently: the proxy dialer used for non-HTTP connections).
+package protocolinit
+
+import (
+	"sync"
+
+	"github.com/projectdiscovery/nuclei/v2/pkg/protocols/common/proxydialer"
+	"github.com/projectdiscovery/nuclei/v2/pkg/types"
+)
+
+var (
+	mu          sync.RWMutex
+	dialer      *proxydialer.Dialer
+	proxySource proxydialer.ProxySource
+)
+
+// SetProxySource registers a proxydialer.ProxySource (typically *proxy.Pool)
+// that the shared dialer consults on every Dial call instead of pinning a
+// single static proxy for the whole run. Call it before Init when a
+// multi-proxy pool was built from options.ProxyURLList.
+func SetProxySource(source proxydialer.ProxySource) {
+	mu.Lock()
+	defer mu.Unlock()
+	proxySource = source
+}
+
+// Init prepares shared protocol state from options. It builds the proxy
+// dialer that raw TCP/TLS executors (network, ssl, websocket) use so that
+// options.ProxyURL / options.ProxySocksURL is honored the same way the HTTP
+// protocol already honors it, instead of being silently bypassed for
+// non-HTTP templates.
+func Init(options *types.Options) error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	switch {
+	case proxySource != nil:
+		dialer = proxydialer.NewPooledDialer(proxySource)
+	case options.ProxyURL != "":
+		dialer = proxydialer.NewDialer(options.ProxyURL)
+	case options.ProxySocksURL != "":
+		dialer = proxydialer.NewDialer(options.ProxySocksURL)
+	default:
+		dialer = nil
+	}
+	return nil
+}
+
+// Dialer returns the dialer configured by Init for non-HTTP protocol
+// executors to use when opening raw TCP/TLS connections. It returns nil if
+// no proxy was configured, in which case callers should dial directly.
+func Dialer() *proxydialer.Dialer {
+	mu.RLock()
+	defer mu.RUnlock()
+	return dialer
+}
+
+// Close releases shared protocol state. It is called once when the runner
+// shuts down.
+func Close() {
+	mu.Lock()
+	defer mu.Unlock()
+	dialer = nil
+}
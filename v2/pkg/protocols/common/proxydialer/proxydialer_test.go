@@ -0,0 +1,105 @@
+package proxydialer
+
+import (
+	"bufio"
+	"errors"
+	"net"
+	"strings"
+	"testing"
+)
+
+func TestDialWithEmptyProxyURLDialsDirectly(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("could not start test listener: %v", err)
+	}
+	defer listener.Close()
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		conn.Close()
+	}()
+
+	dialer := NewDialer("")
+	conn, err := dialer.Dial("tcp", listener.Addr().String())
+	if err != nil {
+		t.Fatalf("Dial() error = %v, want nil", err)
+	}
+	conn.Close()
+}
+
+func TestDialWithUnsupportedSchemeReturnsError(t *testing.T) {
+	dialer := NewDialer("ftp://proxy.example.com:21")
+	if _, err := dialer.Dial("tcp", "example.com:80"); err == nil {
+		t.Error("Dial() with unsupported proxy scheme = nil error, want error")
+	}
+}
+
+type failingProxySource struct{}
+
+func (failingProxySource) GetProxy() (string, error) {
+	return "", errors.New("no healthy proxies in pool")
+}
+
+func TestDialWithPooledDialerWrapsSourceError(t *testing.T) {
+	dialer := NewPooledDialer(failingProxySource{})
+	_, err := dialer.Dial("tcp", "example.com:80")
+	if err == nil {
+		t.Fatal("Dial() with failing ProxySource = nil error, want error")
+	}
+	if !strings.Contains(err.Error(), "no healthy proxy available") {
+		t.Errorf("Dial() error = %q, want it to wrap the ProxySource failure", err.Error())
+	}
+}
+
+// fakeConnectProxy accepts a single connection, reads the CONNECT request
+// line, and replies with statusLine so dialHTTPConnect's response handling
+// can be exercised without a real upstream proxy.
+func fakeConnectProxy(t *testing.T, statusLine string) net.Listener {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("could not start fake proxy listener: %v", err)
+	}
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		reader := bufio.NewReader(conn)
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil || line == "\r\n" || line == "\n" {
+				break
+			}
+		}
+		_, _ = conn.Write([]byte(statusLine + "\r\n\r\n"))
+	}()
+	return listener
+}
+
+func TestDialHTTPConnectSucceedsOn200(t *testing.T) {
+	listener := fakeConnectProxy(t, "HTTP/1.1 200 Connection Established")
+	defer listener.Close()
+
+	dialer := NewDialer("http://" + listener.Addr().String())
+	conn, err := dialer.Dial("tcp", "upstream.example.com:443")
+	if err != nil {
+		t.Fatalf("Dial() error = %v, want nil", err)
+	}
+	conn.Close()
+}
+
+func TestDialHTTPConnectRejectsNon200(t *testing.T) {
+	listener := fakeConnectProxy(t, "HTTP/1.1 407 Proxy Authentication Required")
+	defer listener.Close()
+
+	dialer := NewDialer("http://" + listener.Addr().String())
+	if _, err := dialer.Dial("tcp", "upstream.example.com:443"); err == nil {
+		t.Error("Dial() with non-200 CONNECT response = nil error, want error")
+	}
+}
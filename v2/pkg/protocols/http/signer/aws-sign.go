@@ -7,20 +7,71 @@ import (
 	"errors"
 	"io"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	"github.com/aws/aws-sdk-go-v2/aws/signer/v4a"
 	awsconfig "github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/credentials"
 )
 
+// SignatureVersion selects which AWS request-signing scheme a template uses,
+// set via the `signature:` template option.
+type SignatureVersion string
+
+const (
+	// SignatureV4 is classic, single-region SigV4 signing. This remains the
+	// default when `signature:` is unset.
+	SignatureV4 SignatureVersion = "v4"
+	// SignatureV4a is asymmetric SigV4, whose signature remains valid across
+	// every region in RegionSet (or all regions, via "*"), as required by
+	// multi-region endpoints such as S3 Multi-Region Access Points.
+	SignatureV4a SignatureVersion = "v4a"
+)
+
+// SignerArg is a context key type for per-request signer overrides (e.g.
+// SignerArg("region"), SignerArg("service")) passed down from a template's
+// request options, keeping them out of AWSOptions which is shared across
+// every request a signer signs.
+type SignerArg string
+
+// HTTPSigner is implemented by both AWSSigner (SigV4) and AWSSigV4aSigner
+// (SigV4a) so callers can select an implementation via SignatureVersion
+// without caring which one they got.
+type HTTPSigner interface {
+	SignHTTP(ctx context.Context, request *http.Request) error
+}
+
+// Presigner is implemented by signers that can also emit a presigned URL
+// (query-string credentials) instead of an Authorization header, for
+// templates using `presign: true` / `expires: <duration>`. Both AWSSigner
+// and AWSSigV4aSigner implement it.
+type Presigner interface {
+	PresignHTTP(ctx context.Context, request *http.Request, expiry time.Duration) (signedURL string, signedHeaders http.Header, err error)
+}
+
+// NewSigner builds the HTTPSigner for signatureVersion, loading credentials
+// from the environment/shared config the same way NewAwsSignerFromConfig
+// does.
+func NewSigner(signatureVersion SignatureVersion, opts *AWSOptions) (HTTPSigner, error) {
+	if signatureVersion == SignatureV4a {
+		return NewAwsSigV4aSignerFromConfig(opts)
+	}
+	return NewAwsSignerFromConfig(opts)
+}
+
 // AWSOptions
 type AWSOptions struct {
 	AwsID          string
 	AwsSecretToken string
 	Service        string
 	Region         string
+	// RegionSet lists the regions a SigV4a signature is valid for, sent as
+	// the comma-joined `X-Amz-Region-Set` header. A single "*" signs for
+	// every region. Only used by AWSSigV4aSigner.
+	RegionSet []string
 }
 
 // Validate Signature Arguments
@@ -28,7 +79,7 @@ func (a *AWSOptions) Validate() error {
 	if a.Service == "" {
 		return errors.New("aws service cannot be empty")
 	}
-	if a.Region == "" {
+	if a.Region == "" && len(a.RegionSet) == 0 {
 		return errors.New("aws region cannot be empty")
 	}
 
@@ -54,11 +105,39 @@ func (a *AWSSigner) SignHTTP(ctx context.Context, request *http.Request) error {
 		return err
 	}
 
-	return a.signer.SignHTTP(ctx, *a.creds, request, a.getPayloadHash(request), a.options.Service, a.options.Region, time.Now())
+	return a.signer.SignHTTP(ctx, *a.creds, request, getPayloadHash(request), a.options.Service, a.options.Region, time.Now())
+}
+
+// PresignHTTP signs request as a presigned URL valid for expiry instead of
+// adding an Authorization header, for templates using `presign: true` /
+// `expires: <duration>`. It honors a precomputed X-Amz-Content-Sha256 header
+// if the caller set one, falling back to UNSIGNED-PAYLOAD since the body of
+// a presigned GET is normally unknown ahead of time.
+func (a *AWSSigner) PresignHTTP(ctx context.Context, request *http.Request, expiry time.Duration) (string, http.Header, error) {
+	if region, ok := ctx.Value(SignerArg("region")).(string); ok && region != "" {
+		a.options.Region = region
+	}
+	if service, ok := ctx.Value(SignerArg("service")).(string); ok && service != "" {
+		a.options.Service = service
+	}
+	if err := a.options.Validate(); err != nil {
+		return "", nil, err
+	}
+
+	payloadHash := request.Header.Get("X-Amz-Content-Sha256")
+	if payloadHash == "" {
+		payloadHash = "UNSIGNED-PAYLOAD"
+	}
+
+	query := request.URL.Query()
+	query.Set("X-Amz-Expires", strconv.Itoa(int(expiry.Seconds())))
+	request.URL.RawQuery = query.Encode()
+
+	return a.signer.PresignHTTP(ctx, *a.creds, request, payloadHash, a.options.Service, a.options.Region, time.Now())
 }
 
 // getPayloadHash returns hex encoded SHA-256 of request body
-func (a *AWSSigner) getPayloadHash(request *http.Request) string {
+func getPayloadHash(request *http.Request) string {
 	if request.Body == nil {
 		// Default Hash of Empty Payload
 		return "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
@@ -109,6 +188,114 @@ func NewAwsSignerFromConfig(opts *AWSOptions) (*AWSSigner, error) {
 	}, nil
 }
 
+// AWSSigV4aSigner signs requests with SigV4a (AWS4-ECDSA-P256-SHA256), whose
+// signature stays valid across every region in AWSOptions.RegionSet instead
+// of a single region.
+type AWSSigV4aSigner struct {
+	creds   *aws.Credentials
+	signer  *v4a.Signer
+	options *AWSOptions
+}
+
+// SignHTTP derives an ECDSA P-256 key from the secret access key per the
+// SigV4a spec and signs request with it.
+func (a *AWSSigV4aSigner) SignHTTP(ctx context.Context, request *http.Request) error {
+	if region, ok := ctx.Value(SignerArg("region")).(string); ok && region != "" {
+		a.options.Region = region
+	}
+	if service, ok := ctx.Value(SignerArg("service")).(string); ok && service != "" {
+		a.options.Service = service
+	}
+	if err := a.options.Validate(); err != nil {
+		return err
+	}
+
+	regionSet := a.options.RegionSet
+	if len(regionSet) == 0 {
+		regionSet = []string{a.options.Region}
+	}
+
+	asymmetricCreds, err := (&v4a.SymmetricCredentialAdaptor{
+		SymmetricProvider: credentials.NewStaticCredentialsProvider(a.creds.AccessKeyID, a.creds.SecretAccessKey, a.creds.SessionToken),
+	}).Retrieve(ctx)
+	if err != nil {
+		return err
+	}
+
+	return a.signer.SignHTTP(ctx, asymmetricCreds, request, getPayloadHash(request), a.options.Service, regionSet, time.Now())
+}
+
+// PresignHTTP signs request as a SigV4a presigned URL valid for expiry,
+// mirroring AWSSigner.PresignHTTP so `presign: true` works the same way
+// regardless of the chosen `signature:` version.
+func (a *AWSSigV4aSigner) PresignHTTP(ctx context.Context, request *http.Request, expiry time.Duration) (string, http.Header, error) {
+	if region, ok := ctx.Value(SignerArg("region")).(string); ok && region != "" {
+		a.options.Region = region
+	}
+	if service, ok := ctx.Value(SignerArg("service")).(string); ok && service != "" {
+		a.options.Service = service
+	}
+	if err := a.options.Validate(); err != nil {
+		return "", nil, err
+	}
+
+	regionSet := a.options.RegionSet
+	if len(regionSet) == 0 {
+		regionSet = []string{a.options.Region}
+	}
+
+	payloadHash := request.Header.Get("X-Amz-Content-Sha256")
+	if payloadHash == "" {
+		payloadHash = "UNSIGNED-PAYLOAD"
+	}
+
+	query := request.URL.Query()
+	query.Set("X-Amz-Expires", strconv.Itoa(int(expiry.Seconds())))
+	request.URL.RawQuery = query.Encode()
+
+	asymmetricCreds, err := (&v4a.SymmetricCredentialAdaptor{
+		SymmetricProvider: credentials.NewStaticCredentialsProvider(a.creds.AccessKeyID, a.creds.SecretAccessKey, a.creds.SessionToken),
+	}).Retrieve(ctx)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return a.signer.PresignHTTP(ctx, asymmetricCreds, request, payloadHash, a.options.Service, regionSet, time.Now())
+}
+
+// NewAwsSigV4aSigner builds an AWSSigV4aSigner from static credentials.
+func NewAwsSigV4aSigner(opts *AWSOptions) (*AWSSigV4aSigner, error) {
+	credcache := aws.NewCredentialsCache(credentials.NewStaticCredentialsProvider(opts.AwsID, opts.AwsSecretToken, ""))
+	awscred, err := credcache.Retrieve(context.TODO())
+	if err != nil {
+		return nil, err
+	}
+	return &AWSSigV4aSigner{
+		creds:   &awscred,
+		options: opts,
+		signer:  v4a.NewSigner(func(signer *v4a.SignerOptions) {}),
+	}, nil
+}
+
+// NewAwsSigV4aSignerFromConfig builds an AWSSigV4aSigner from the
+// environment/shared config, same as NewAwsSignerFromConfig.
+func NewAwsSigV4aSignerFromConfig(opts *AWSOptions) (*AWSSigV4aSigner, error) {
+	cfg, err := awsconfig.LoadDefaultConfig(context.TODO())
+	if err != nil {
+		return nil, err
+	}
+	credcache := aws.NewCredentialsCache(cfg.Credentials)
+	awscred, err := credcache.Retrieve(context.TODO())
+	if err != nil {
+		return nil, err
+	}
+	return &AWSSigV4aSigner{
+		creds:   &awscred,
+		options: opts,
+		signer:  v4a.NewSigner(func(signer *v4a.SignerOptions) {}),
+	}, nil
+}
+
 var AwsSkipList = map[string]interface{}{
 	"region": struct{}{},
 }
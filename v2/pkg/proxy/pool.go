@@ -0,0 +1,344 @@
+// Package proxy implements a health-checked pool of upstream proxies that
+// can be handed out to protocol executors one request at a time.
+package proxy
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"math/rand"
+	"net"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/projectdiscovery/gologger"
+)
+
+// Strategy determines how a proxy is picked from the pool for a given request.
+type Strategy string
+
+const (
+	// RoundRobin cycles through healthy proxies in order.
+	RoundRobin Strategy = "round-robin"
+	// Random picks a healthy proxy at random.
+	Random Strategy = "random"
+	// LatencyWeighted favors proxies with lower observed latency.
+	LatencyWeighted Strategy = "latency-weighted"
+	// Fallback sticks to a primary proxy until it degrades, then promotes
+	// the first healthy proxy in the list.
+	Fallback Strategy = "fallback"
+)
+
+// DefaultHealthInterval is used when Options.HealthInterval is unset.
+const DefaultHealthInterval = 30 * time.Second
+
+// Options configures a Pool.
+type Options struct {
+	// Strategy selects how proxies are handed out.
+	Strategy Strategy
+	// HealthInterval is the period between health-check passes.
+	HealthInterval time.Duration
+	// ProbeURL is dialed/requested to score proxy health. When empty, a
+	// plain TCP dial to the proxy itself is used.
+	ProbeURL string
+	// DialTimeout bounds each individual health-check attempt.
+	DialTimeout time.Duration
+}
+
+// entry tracks the health state of a single proxy in the pool.
+type entry struct {
+	url string
+
+	mu        sync.Mutex
+	healthy   bool
+	latency   time.Duration
+	successes uint64
+	failures  uint64
+}
+
+func (e *entry) score() float64 {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if !e.healthy {
+		return 0
+	}
+	total := e.successes + e.failures
+	if total == 0 {
+		return 1
+	}
+	successRate := float64(e.successes) / float64(total)
+	// lower latency and higher success rate both increase score
+	latencyPenalty := float64(e.latency.Milliseconds()) + 1
+	return successRate * 1000 / latencyPenalty
+}
+
+// Pool is a collection of proxies that are health-checked in the background
+// and handed out to callers via GetProxy according to the configured Strategy.
+type Pool struct {
+	options Options
+
+	mu      sync.RWMutex
+	entries []*entry
+
+	primaryMu sync.Mutex
+	primary   int // index of the current primary proxy, used by Fallback
+
+	// rrCounter is incremented by every GetProxy call under RoundRobin, which
+	// run concurrently per-dial, so it's an atomic counter rather than a
+	// plain uint64 guarded by p.mu's read lock.
+	rrCounter uint64
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// New creates a new proxy Pool from a list of proxy URLs and starts the
+// background health-checker. Call Close to stop it.
+func New(proxyURLs []string, options Options) (*Pool, error) {
+	if len(proxyURLs) == 0 {
+		return nil, errors.New("no proxies provided to pool")
+	}
+	if options.Strategy == "" {
+		options.Strategy = RoundRobin
+	}
+	if options.HealthInterval <= 0 {
+		options.HealthInterval = DefaultHealthInterval
+	}
+	if options.DialTimeout <= 0 {
+		options.DialTimeout = 5 * time.Second
+	}
+
+	entries := make([]*entry, 0, len(proxyURLs))
+	for _, proxyURL := range proxyURLs {
+		entries = append(entries, &entry{url: proxyURL, healthy: true})
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	pool := &Pool{
+		options: options,
+		entries: entries,
+		ctx:     ctx,
+		cancel:  cancel,
+	}
+
+	pool.checkAll()
+	pool.wg.Add(1)
+	go pool.healthCheckLoop()
+
+	return pool, nil
+}
+
+// Close stops the background health-checker.
+func (p *Pool) Close() {
+	p.cancel()
+	p.wg.Wait()
+}
+
+// GetProxy returns a proxy URL chosen according to the pool's strategy.
+// It returns an error if no healthy proxy is currently available.
+func (p *Pool) GetProxy() (string, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	switch p.options.Strategy {
+	case Random:
+		return p.pickRandom()
+	case LatencyWeighted:
+		return p.pickLatencyWeighted()
+	case Fallback:
+		return p.pickFallback()
+	default:
+		return p.pickRoundRobin()
+	}
+}
+
+func (p *Pool) healthyEntries() []*entry {
+	healthy := make([]*entry, 0, len(p.entries))
+	for _, e := range p.entries {
+		e.mu.Lock()
+		isHealthy := e.healthy
+		e.mu.Unlock()
+		if isHealthy {
+			healthy = append(healthy, e)
+		}
+	}
+	return healthy
+}
+
+func (p *Pool) pickRoundRobin() (string, error) {
+	healthy := p.healthyEntries()
+	if len(healthy) == 0 {
+		return "", errors.New("no healthy proxies available")
+	}
+	idx := atomic.AddUint64(&p.rrCounter, 1) - 1
+	return healthy[idx%uint64(len(healthy))].url, nil
+}
+
+func (p *Pool) pickRandom() (string, error) {
+	healthy := p.healthyEntries()
+	if len(healthy) == 0 {
+		return "", errors.New("no healthy proxies available")
+	}
+	return healthy[rand.Intn(len(healthy))].url, nil
+}
+
+func (p *Pool) pickLatencyWeighted() (string, error) {
+	healthy := p.healthyEntries()
+	if len(healthy) == 0 {
+		return "", errors.New("no healthy proxies available")
+	}
+	best := healthy[0]
+	bestScore := best.score()
+	for _, e := range healthy[1:] {
+		if score := e.score(); score > bestScore {
+			best, bestScore = e, score
+		}
+	}
+	return best.url, nil
+}
+
+func (p *Pool) pickFallback() (string, error) {
+	p.primaryMu.Lock()
+	primaryIdx := p.primary
+	p.primaryMu.Unlock()
+
+	if primaryIdx < len(p.entries) {
+		primary := p.entries[primaryIdx]
+		primary.mu.Lock()
+		isHealthy := primary.healthy
+		primary.mu.Unlock()
+		if isHealthy {
+			return primary.url, nil
+		}
+	}
+	healthy := p.healthyEntries()
+	if len(healthy) == 0 {
+		return "", errors.New("no healthy proxies available")
+	}
+	// promote the first healthy proxy found to primary
+	p.primaryMu.Lock()
+	for i, e := range p.entries {
+		if e == healthy[0] {
+			p.primary = i
+			break
+		}
+	}
+	p.primaryMu.Unlock()
+	return healthy[0].url, nil
+}
+
+func (p *Pool) healthCheckLoop() {
+	defer p.wg.Done()
+	ticker := time.NewTicker(p.options.HealthInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-p.ctx.Done():
+			return
+		case <-ticker.C:
+			p.checkAll()
+		}
+	}
+}
+
+func (p *Pool) checkAll() {
+	p.mu.RLock()
+	entries := p.entries
+	p.mu.RUnlock()
+
+	var wg sync.WaitGroup
+	for _, e := range entries {
+		wg.Add(1)
+		go func(e *entry) {
+			defer wg.Done()
+			p.checkOne(e)
+		}(e)
+	}
+	wg.Wait()
+}
+
+func (p *Pool) checkOne(e *entry) {
+	start := time.Now()
+	err := probe(e.url, p.options.ProbeURL, p.options.DialTimeout)
+	latency := time.Since(start)
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if err != nil {
+		e.healthy = false
+		e.failures++
+		gologger.Verbose().Msgf("Proxy %s failed health check: %s\n", e.url, err)
+		return
+	}
+	e.healthy = true
+	e.successes++
+	e.latency = latency
+}
+
+// probe performs a TCP dial to the proxy, and if a probe URL is configured,
+// an HTTP CONNECT handshake through it to validate end-to-end reachability.
+func probe(proxyURL, probeURL string, timeout time.Duration) error {
+	parsed, err := url.Parse(proxyURL)
+	if err != nil {
+		return err
+	}
+	dialer := net.Dialer{Timeout: timeout}
+	conn, err := dialer.Dial("tcp", parsed.Host)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if probeURL == "" {
+		return nil
+	}
+	return connectHandshake(conn, parsed, probeURL, timeout)
+}
+
+// connectHandshake issues an HTTP CONNECT request for probeURL's host over
+// conn and verifies the proxy responds with a successful status line.
+func connectHandshake(conn net.Conn, proxyURL *url.URL, probeURL string, timeout time.Duration) error {
+	target, err := url.Parse(probeURL)
+	if err != nil {
+		return err
+	}
+	host := target.Host
+	if target.Port() == "" {
+		if target.Scheme == "https" {
+			host = net.JoinHostPort(target.Hostname(), "443")
+		} else {
+			host = net.JoinHostPort(target.Hostname(), "80")
+		}
+	}
+
+	_ = conn.SetDeadline(time.Now().Add(timeout))
+	req := "CONNECT " + host + " HTTP/1.1\r\nHost: " + host + "\r\n"
+	if proxyURL.User != nil {
+		if password, ok := proxyURL.User.Password(); ok {
+			req += "Proxy-Authorization: Basic " + basicAuth(proxyURL.User.Username(), password) + "\r\n"
+		}
+	}
+	req += "\r\n"
+	if _, err := conn.Write([]byte(req)); err != nil {
+		return err
+	}
+
+	buf := make([]byte, 128)
+	n, err := conn.Read(buf)
+	if err != nil {
+		return err
+	}
+	response := string(buf[:n])
+	if !strings.Contains(response, " 200 ") {
+		return errors.New("proxy probe CONNECT failed: " + strings.TrimSpace(response))
+	}
+	return nil
+}
+
+func basicAuth(username, password string) string {
+	return base64.StdEncoding.EncodeToString([]byte(username + ":" + password))
+}